@@ -0,0 +1,30 @@
+package errors
+
+// Code is a machine-readable code identifying a kind of error, e.g. "DB_TIMEOUT". Errors sharing the same code are
+// matched by Is.
+type Code string
+
+// CodeOf returns the code of the nearest Err, *Err or Coder in the chain of err holding one, or an empty Code if none
+// does.
+func CodeOf(err error) Code {
+	for ; err != nil; err = unwrap(err) {
+		if code := codeOf(err); code != "" {
+			return code
+		}
+	}
+
+	return ""
+}
+
+// codeOf returns the code of err if it is an Err, *Err or a Coder.
+func codeOf(err error) Code {
+	if e, ok := err.(*Err); ok && e == nil {
+		return ""
+	}
+
+	if c, ok := err.(Coder); ok {
+		return c.ErrorCode()
+	}
+
+	return ""
+}
@@ -0,0 +1,92 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const codeDBTimeout Code = "DB_TIMEOUT"
+
+func TestCodeOf(t *testing.T) {
+	t.Run("when CodeOf is provided with a chain holding a code, it should return the nearest code", func(t *testing.T) {
+		err := Wrap(fmt.Errorf("query failed: %w", WrapCode(NewCode("NET_TIMEOUT", "i/o timeout"), codeDBTimeout, "database timeout")), "failed")
+
+		if got := CodeOf(err); got != codeDBTimeout {
+			t.Errorf(`unexpected code, got "%s", expected "%s"`, got, codeDBTimeout)
+		}
+	})
+
+	t.Run("when CodeOf is provided with a chain without codes, it should return an empty code", func(t *testing.T) {
+		if got := CodeOf(Wrap(New("failed"), "wrapped")); got != "" {
+			t.Errorf(`unexpected code, got "%s", expected an empty code`, got)
+		}
+	})
+}
+
+func TestCodeIs(t *testing.T) {
+	t.Run("when two errors share the same code, Is should match them", func(t *testing.T) {
+		err := Wrap(NewCodef(codeDBTimeout, "timeout after %ds", 30), "failed to update the database")
+
+		if !Is(err, NewCode(codeDBTimeout, "another timeout")) {
+			t.Errorf("expected Is to return true, got false")
+		}
+	})
+
+	t.Run("when two errors have different codes, Is should not match them", func(t *testing.T) {
+		err := WrapCodef(New("failed"), codeDBTimeout, "timeout after %ds", 30)
+
+		if Is(err, NewCode("DB_CONFLICT", "conflict")) {
+			t.Errorf("expected Is to return false, got true")
+		}
+	})
+
+	t.Run("when two errors have no code, Is should not match them", func(t *testing.T) {
+		if Is(New("failed"), New("failed")) {
+			t.Errorf("expected Is to return false, got true")
+		}
+	})
+}
+
+func TestCodeOutput(t *testing.T) {
+	t.Run("when an error with a code is formatted with %+v, it should include the code", func(t *testing.T) {
+		err := WrapCode(New("i/o timeout"), codeDBTimeout, "database timeout")
+
+		outputStr := fmt.Sprintf("%+v", err)
+		if !strings.Contains(outputStr, "message:\n\t\"database timeout\"\ncode:\n\tDB_TIMEOUT\n") {
+			t.Errorf(`expected the code to be in the output string, got "%v"`, outputStr)
+		}
+	})
+
+	t.Run("when an error with a code is marshaled, it should include the code and restore it", func(t *testing.T) {
+		err := WrapCode(New("i/o timeout"), codeDBTimeout, "database timeout")
+
+		b, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		var errs []map[string]any
+		if jsonErr := json.Unmarshal(b, &errs); jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		if errs[0]["code"] != string(codeDBTimeout) {
+			t.Errorf(`unexpected code, got "%v", expected "%s"`, errs[0]["code"], codeDBTimeout)
+		}
+
+		if _, ok := errs[1]["code"]; ok {
+			t.Errorf("unexpected code, got %v, expected undefined key", errs[1]["code"])
+		}
+
+		restored, jsonErr := FromJSON(b)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		if restored.Code != codeDBTimeout {
+			t.Errorf(`unexpected code, got "%s", expected "%s"`, restored.Code, codeDBTimeout)
+		}
+	})
+}
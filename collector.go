@@ -0,0 +1,68 @@
+package errors
+
+import "sync"
+
+// Collector collects errors reported by concurrent goroutines into a MultiError. It is safe for concurrent use and its
+// zero value is ready to use.
+type Collector struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add adds err to the collected errors. Nil errors are ignored.
+func (c *Collector) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errs = append(c.errs, err)
+}
+
+// Len returns the number of collected errors.
+func (c *Collector) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.errs)
+}
+
+// Err returns an errors.MultiError holding the collected errors, or nil if no error was collected.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(c.errs))
+	copy(errs, c.errs)
+
+	return NewMulti(errs...)
+}
+
+// Group runs functions in their own goroutines and collects every error they return, unlike errgroup.Group which
+// only keeps the first one. Its zero value is ready to use.
+type Group struct {
+	wg   sync.WaitGroup
+	errs Collector
+}
+
+// Go runs f in a new goroutine, the error it returns is collected by the group.
+func (g *Group) Go(f func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.errs.Add(f())
+	}()
+}
+
+// Wait blocks until all the functions run by Go have returned, then returns an errors.MultiError holding their
+// errors, or nil if none of them failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	return g.errs.Err()
+}
@@ -0,0 +1,96 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	t.Run("when errors are added concurrently, it should collect all of them", func(t *testing.T) {
+		var c Collector
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				c.Add(Errorf("failed %d", i))
+			}(i)
+		}
+		wg.Wait()
+
+		if got := c.Len(); got != 100 {
+			t.Fatalf("unexpected number of errors, got %d, expected %d", got, 100)
+		}
+
+		multiErr, ok := c.Err().(*MultiError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *MultiError", c.Err())
+		}
+
+		if len(multiErr.Errors) != 100 {
+			t.Errorf("unexpected number of errors, got %d, expected %d", len(multiErr.Errors), 100)
+		}
+	})
+
+	t.Run("when no error is added, Err should return nil", func(t *testing.T) {
+		var c Collector
+		c.Add(nil)
+
+		if err := c.Err(); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+
+		if got := c.Len(); got != 0 {
+			t.Errorf("unexpected number of errors, got %d, expected %d", got, 0)
+		}
+	})
+}
+
+func TestGroup(t *testing.T) {
+	t.Run("when functions of a group fail, Wait should return all their errors", func(t *testing.T) {
+		var g Group
+		for i := 0; i < 10; i++ {
+			i := i
+			g.Go(func() error {
+				if i%2 == 0 {
+					return Errorf("failed %d", i)
+				}
+				return nil
+			})
+		}
+
+		err := g.Wait()
+
+		multiErr, ok := err.(*MultiError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *MultiError", err)
+		}
+
+		if len(multiErr.Errors) != 5 {
+			t.Errorf("unexpected number of errors, got %d, expected %d", len(multiErr.Errors), 5)
+		}
+
+		for i := 0; i < 10; i += 2 {
+			found := false
+			for _, e := range multiErr.Errors {
+				if e.Error() == fmt.Sprintf("failed %d", i) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf(`expected "failed %d" to be collected`, i)
+			}
+		}
+	})
+
+	t.Run("when no function of a group fails, Wait should return nil", func(t *testing.T) {
+		var g Group
+		g.Go(func() error { return nil })
+
+		if err := g.Wait(); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+}
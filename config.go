@@ -0,0 +1,84 @@
+package errors
+
+import (
+	"os"
+	"strconv"
+	"sync/atomic"
+)
+
+// defaultStackDepth is the maximum number of frames captured by default.
+const defaultStackDepth = 32
+
+// StackCaptureEnv is the environment variable read at initialization to enable or disable stack capture, e.g.
+// ZIGND_ERRORS_STACK=false disables it. Any value accepted by strconv.ParseBool is valid.
+const StackCaptureEnv = "ZIGND_ERRORS_STACK"
+
+var (
+	stackDepth   atomic.Int32
+	stackCapture atomic.Bool
+	stackDedup   atomic.Bool
+	formatter    atomic.Pointer[Formatter]
+)
+
+func init() {
+	stackDepth.Store(defaultStackDepth)
+	stackCapture.Store(true)
+
+	if v, ok := os.LookupEnv(StackCaptureEnv); ok {
+		if enabled, err := strconv.ParseBool(v); err == nil {
+			stackCapture.Store(enabled)
+		}
+	}
+}
+
+// SetStackDepth sets the maximum number of frames captured for new errors. Values lower than 1 are ignored.
+func SetStackDepth(depth int) {
+	if depth < 1 {
+		return
+	}
+
+	stackDepth.Store(int32(depth))
+}
+
+// StackDepth returns the maximum number of frames captured for new errors.
+func StackDepth() int {
+	return int(stackDepth.Load())
+}
+
+// SetStackCapture enables or disables the capture of stack traces for new errors. When disabled, errors are created
+// with a nil Stack.
+func SetStackCapture(enabled bool) {
+	stackCapture.Store(enabled)
+}
+
+// StackCaptureEnabled reports whether stack traces are captured for new errors.
+func StackCaptureEnabled() bool {
+	return stackCapture.Load()
+}
+
+// SetStackDedup enables or disables the deduplication of stack traces across wrapped causes. When enabled, the %+v
+// output and MarshalJSON only render the frames of each wrapper's stack that are not already present in the stack of
+// its cause, followed by a "... N more" line.
+func SetStackDedup(enabled bool) {
+	stackDedup.Store(enabled)
+}
+
+// StackDedupEnabled reports whether stack traces are deduplicated across wrapped causes.
+func StackDedupEnabled() bool {
+	return stackDedup.Load()
+}
+
+// SetFormatter sets the Formatter used by the %+v format of Err and MultiError.
+func SetFormatter(f Formatter) {
+	formatter.Store(&f)
+}
+
+// CurrentFormatter returns the Formatter used by the %+v format of Err and MultiError, which is VerboseFormatter
+// unless another one was set with SetFormatter.
+func CurrentFormatter() Formatter {
+	if f := formatter.Load(); f != nil && *f != nil {
+		return *f
+	}
+
+	return VerboseFormatter
+}
@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"strings"
+	"testing"
+)
+
+// newHelperError is a helper function that creates errors pointing at its caller.
+func newHelperError(msg string) error {
+	return WithStackSkip(&Err{Message: msg}, 1)
+}
+
+func TestSetStackDepth(t *testing.T) {
+	t.Run("when the stack depth is set, it should limit the number of captured frames", func(t *testing.T) {
+		defer SetStackDepth(StackDepth())
+		SetStackDepth(1)
+
+		if err := New("error message").(*Err); len(err.Stack) != 1 {
+			t.Errorf("unexpected number of frames, got %d, expected %d", len(err.Stack), 1)
+		}
+	})
+
+	t.Run("when the stack depth is set beyond the default, it should capture deeper stacks", func(t *testing.T) {
+		defer SetStackDepth(StackDepth())
+		SetStackDepth(defaultStackDepth * 2)
+
+		var err error
+		var recurse func(n int)
+		recurse = func(n int) {
+			if n == 0 {
+				err = New("error message")
+				return
+			}
+			recurse(n - 1)
+		}
+		recurse(defaultStackDepth * 2)
+
+		if got := len(err.(*Err).Stack); got != defaultStackDepth*2 {
+			t.Errorf("unexpected number of frames, got %d, expected %d", got, defaultStackDepth*2)
+		}
+	})
+
+	t.Run("when the stack depth is set to an invalid value, it should be ignored", func(t *testing.T) {
+		defer SetStackDepth(StackDepth())
+		SetStackDepth(10)
+		SetStackDepth(0)
+
+		if got := StackDepth(); got != 10 {
+			t.Errorf("unexpected stack depth, got %d, expected %d", got, 10)
+		}
+	})
+}
+
+func TestSetStackCapture(t *testing.T) {
+	t.Run("when stack capture is disabled, errors should be created without a stack", func(t *testing.T) {
+		defer SetStackCapture(StackCaptureEnabled())
+		SetStackCapture(false)
+
+		if err := New("error message").(*Err); err.Stack != nil {
+			t.Errorf("expected stack to be nil, got %v", err.Stack)
+		}
+
+		if err := Wrap(New("inner"), "outer").(*Err); err.Stack != nil {
+			t.Errorf("expected stack to be nil, got %v", err.Stack)
+		}
+	})
+}
+
+func TestCallers(t *testing.T) {
+	t.Run("when Callers is provided with a skip of 0, it should start at the caller of Callers", func(t *testing.T) {
+		frames := Callers(0).Frames()
+		if !strings.Contains(frames[0].Function, "TestCallers") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestCallers")
+		}
+	})
+
+	t.Run("when WithStackSkip is used by a helper function, the stack should point at the caller of the helper", func(t *testing.T) {
+		err := newHelperError("error message").(*Err)
+
+		frames := err.Stack.Frames()
+		if !strings.Contains(frames[0].Function, "TestCallers") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestCallers")
+		}
+	})
+}
@@ -1,12 +1,14 @@
 package errors
 
-import "errors"
+import (
+	"encoding/json"
+)
 
 // toMapsSlice converts an error and its causes to flat slice of maps where each map represents an error.
 func toMapsSlice(err error) []map[string]any {
 	errMaps := make([]map[string]any, 0)
 
-	if err == nil {
+	if isNil(err) {
 		return errMaps
 	}
 
@@ -23,22 +25,122 @@ func toMapsSlice(err error) []map[string]any {
 	return errMaps
 }
 
-// toMapAndCause converts an error to a map and extracts the cause.
+// toMapAndCause converts an error to a map and extracts the cause. The errors wrapped by an error wrapping multiple
+// errors are converted into a "causes" entry holding the flat slice of maps of each one of them.
 func toMapAndCause(err error) (map[string]any, error) {
 	errMap := make(map[string]any)
 	var errCause error
 
-	if e, ok := err.(*Err); ok {
+	if e, ok := toErr(err); ok {
 		errMap["message"] = e.Message
+		if e.Code != "" {
+			errMap["code"] = e.Code
+		}
 		if e.Data != nil {
-			errMap["data"] = e.Data
+			errMap["data"] = orderedData{data: e.Data, order: e.DataOrder}
+		}
+		if StackDedupEnabled() && e.Stack != nil {
+			errMap["stack"] = stackLines(e)
+		} else {
+			errMap["stack"] = e.Stack
 		}
-		errMap["stack"] = e.Stack
 		errCause = e.Cause
+	} else if multi, ok := err.(multiUnwrapper); ok {
+		errMap["message"] = err.Error()
+		causes := make([][]map[string]any, 0)
+		for _, child := range multi.Unwrap() {
+			if !isNil(child) {
+				causes = append(causes, toMapsSlice(child))
+			}
+		}
+		errMap["causes"] = causes
 	} else {
 		errMap["message"] = err.Error()
-		errCause = errors.Unwrap(err)
+		errCause = unwrap(err)
 	}
 
 	return errMap, errCause
 }
+
+// errEntry is an entry of the flat slice produced by toMapsSlice.
+type errEntry struct {
+	Message string          `json:"message"`
+	Code    Code            `json:"code"`
+	Data    Data            `json:"data"`
+	Stack   json.RawMessage `json:"stack"`
+	Causes  [][]errEntry    `json:"causes"`
+}
+
+// fromEntries converts a flat slice of entries produced by toMapsSlice back into a chain of errors. Entries holding
+// a stack are restored as *Err values, while the remaining ones, which came from errors of other types, are restored
+// as plainErr values, or plainMultiErr values if they wrapped multiple errors.
+func fromEntries(entries []errEntry) (error, error) {
+	var cause error
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+
+		if entry.Causes != nil {
+			causes := make([]error, 0, len(entry.Causes))
+			for _, childEntries := range entry.Causes {
+				child, err := fromEntries(childEntries)
+				if err != nil {
+					return nil, err
+				}
+				if child != nil {
+					causes = append(causes, child)
+				}
+			}
+			cause = &plainMultiErr{msg: entry.Message, causes: causes}
+			continue
+		}
+
+		if entry.Stack == nil {
+			cause = &plainErr{msg: entry.Message, cause: cause}
+			continue
+		}
+
+		var stack Stack
+		if err := json.Unmarshal(entry.Stack, &stack); err != nil {
+			return nil, err
+		}
+
+		cause = &Err{
+			Message: entry.Message,
+			Code:    entry.Code,
+			Data:    entry.Data,
+			Stack:   stack,
+			Cause:   cause,
+		}
+	}
+
+	return cause, nil
+}
+
+// plainErr is used to restore the errors of a chain that were not of the type Err when the chain was marshalled.
+type plainErr struct {
+	msg   string
+	cause error
+}
+
+func (e *plainErr) Error() string {
+	return e.msg
+}
+
+func (e *plainErr) Unwrap() error {
+	return e.cause
+}
+
+// plainMultiErr is used to restore the errors wrapping multiple errors that were not of the type Err when the chain
+// was marshalled.
+type plainMultiErr struct {
+	msg    string
+	causes []error
+}
+
+func (e *plainMultiErr) Error() string {
+	return e.msg
+}
+
+func (e *plainMultiErr) Unwrap() []error {
+	return e.causes
+}
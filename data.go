@@ -0,0 +1,278 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// Data holds additional data attached to an error. Its keys are rendered in sorted order by the %+v format and
+// MarshalJSON, unless the error holding it sets Err.DataOrder, e.g. through DataBuilder.Attach.
+type Data map[string]any
+
+// Keys returns the keys of d in the order they are rendered by the %+v format and MarshalJSON. The keys of d listed in
+// order come first, in that order, followed by the remaining ones in sorted order.
+func (d Data) Keys(order ...string) []string {
+	ordered := make(map[string]bool, len(order))
+
+	keys := make([]string, 0, len(d))
+	for _, k := range order {
+		if _, ok := d[k]; ok && !ordered[k] {
+			ordered[k] = true
+			keys = append(keys, k)
+		}
+	}
+
+	rest := make([]string, 0, len(d)-len(keys))
+	for k := range d {
+		if !ordered[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// MarshalJSON implements json.Marshaler. The keys are marshalled in sorted order, and the values are redacted
+// according to the redaction policy.
+func (d Data) MarshalJSON() ([]byte, error) {
+	return orderedData{data: d}.MarshalJSON()
+}
+
+// orderedData is the Data of an error along with its Err.DataOrder, which is marshalled by toMapAndCause.
+type orderedData struct {
+	data  Data
+	order []string
+}
+
+// MarshalJSON implements json.Marshaler. The keys are marshalled in the same order used by the %+v format, and the
+// values are redacted according to the redaction policy.
+func (o orderedData) MarshalJSON() ([]byte, error) {
+	if o.data == nil {
+		return []byte("null"), nil
+	}
+
+	d := o.data.Redacted()
+
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, k := range d.Keys(o.order...) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(d[k])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// Precedence defines which value DataOf keeps when multiple errors of a chain hold the same key.
+type Precedence int
+
+const (
+	// OuterFirst keeps the values of the outermost errors, which is the default.
+	OuterFirst Precedence = iota
+	// InnerFirst keeps the values of the innermost errors.
+	InnerFirst
+)
+
+// DataOf returns the data of every Err, *Err and DataProvider in the chain of err merged into a single Data, looking
+// into errors wrapping multiple errors and errors of other types. When multiple errors hold the same key, the value
+// kept depends on the optional precedence, which is OuterFirst if omitted. It returns nil if no error in the chain
+// holds data.
+func DataOf(err error, precedence ...Precedence) Data {
+	var datas []Data
+	walk(err, func(e error) bool {
+		if d := dataOf(e); d != nil {
+			datas = append(datas, d)
+		}
+		return true
+	})
+
+	if len(datas) == 0 {
+		return nil
+	}
+
+	p := OuterFirst
+	if len(precedence) > 0 {
+		p = precedence[0]
+	}
+
+	merged := make(Data)
+	for i := range datas {
+		d := datas[i]
+		if p == OuterFirst {
+			d = datas[len(datas)-1-i]
+		}
+
+		for _, k := range d.Keys() {
+			merged[k] = d[k]
+		}
+	}
+
+	return merged
+}
+
+// Lookup returns the value of key held by the nearest Err, *Err or DataProvider in the chain of err, looking into
+// errors wrapping multiple errors and errors of other types.
+func Lookup(err error, key string) (any, bool) {
+	var value any
+	var found bool
+	walk(err, func(e error) bool {
+		if d := dataOf(e); d != nil {
+			value, found = d[key]
+		}
+		return !found
+	})
+
+	return value, found
+}
+
+// LookupString returns the value of key held by the nearest error in the chain of err, as Lookup does, if it is a
+// string.
+func LookupString(err error, key string) (string, bool) {
+	value, ok := Lookup(err, key)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := value.(string)
+	return s, ok
+}
+
+// LookupInt returns the value of key held by the nearest error in the chain of err, as Lookup does, if it is an
+// integer. Values of any integer type are converted to int, as well as float64 values without a fractional part,
+// which is how numbers are restored from JSON.
+func LookupInt(err error, key string) (int, bool) {
+	value, ok := Lookup(err, key)
+	if !ok {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	}
+
+	return 0, false
+}
+
+// mergeData returns a copy of d with the values of other set.
+func mergeData(d, other Data) Data {
+	data := make(Data, len(d)+len(other))
+	for k, v := range d {
+		data[k] = v
+	}
+	for k, v := range other {
+		data[k] = v
+	}
+	return data
+}
+
+// mergeOrder returns a copy of order followed by the keys of other it does not hold yet.
+func mergeOrder(order, other []string) []string {
+	if len(other) == 0 {
+		return order
+	}
+
+	seen := make(map[string]bool, len(order))
+	merged := make([]string, 0, len(order)+len(other))
+	for _, k := range order {
+		seen[k] = true
+		merged = append(merged, k)
+	}
+	for _, k := range other {
+		if !seen[k] {
+			seen[k] = true
+			merged = append(merged, k)
+		}
+	}
+	return merged
+}
+
+// dataOf returns the data of err if it is an Err, *Err or a DataProvider.
+func dataOf(err error) Data {
+	if e, ok := err.(*Err); ok && e == nil {
+		return nil
+	}
+
+	if dp, ok := err.(DataProvider); ok {
+		return dp.ErrorData()
+	}
+
+	return nil
+}
+
+// DataBuilder builds a Data while recording the insertion order of its keys, which is rendered by the %+v format and
+// MarshalJSON once attached to an error with Attach.
+type DataBuilder struct {
+	data Data
+	keys []string
+}
+
+// NewData returns a new DataBuilder.
+func NewData() *DataBuilder {
+	return &DataBuilder{data: make(Data)}
+}
+
+// Set sets the value of key. Setting an existing key updates its value but keeps its original position.
+func (b *DataBuilder) Set(key string, value any) *DataBuilder {
+	if _, ok := b.data[key]; !ok {
+		b.keys = append(b.keys, key)
+	}
+	b.data[key] = value
+	return b
+}
+
+// Build returns a copy of the Data built so far.
+func (b *DataBuilder) Build() Data {
+	return mergeData(nil, b.data)
+}
+
+// Keys returns the keys of the Data built so far in insertion order.
+func (b *DataBuilder) Keys() []string {
+	return append([]string(nil), b.keys...)
+}
+
+// Attach returns a copy of err with the Data built so far merged into its own, as WithData does, and its keys
+// appended to the Err.DataOrder of err, so they are rendered in insertion order.
+func (b *DataBuilder) Attach(err error) error {
+	return withData(err, b.Build(), b.Keys(), 4)
+}
@@ -0,0 +1,224 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// dataLines returns the lines of the "data:" section of the %+v output of err.
+func dataLines(err error) string {
+	outputStr := fmt.Sprintf("%+v", err)
+	start := strings.Index(outputStr, "data:\n")
+	end := strings.Index(outputStr, "\nstack:")
+	return outputStr[start:end]
+}
+
+func TestDataOrder(t *testing.T) {
+	t.Run("when an error created with Errord is formatted with %+v, its data keys should be rendered in sorted order", func(t *testing.T) {
+		err := Errord(Data{
+			"severity": "high",
+			"network":  "internal",
+			"attempts": 3,
+			"zone":     "us-east-1",
+			"id":       "abc",
+		}, "network instability detected")
+
+		expected := "data:\n\tattempts: 3\n\tid: abc\n\tnetwork: internal\n\tseverity: high\n\tzone: us-east-1"
+		for i := 0; i < 20; i++ {
+			if got := dataLines(err); got != expected {
+				t.Fatalf(`unexpected data output, got "%s", expected "%s"`, got, expected)
+			}
+		}
+	})
+
+	t.Run("when an error created with Wrapd is formatted with %+v, its data keys should be rendered in sorted order", func(t *testing.T) {
+		err := Wrapd(New("context timeout"), Data{
+			"tableName": "transactions",
+			"operation": "update",
+			"database":  "main",
+		}, "failed to update the database")
+
+		expected := "data:\n\tdatabase: main\n\toperation: update\n\ttableName: transactions"
+		for i := 0; i < 20; i++ {
+			if got := dataLines(err); got != expected {
+				t.Fatalf(`unexpected data output, got "%s", expected "%s"`, got, expected)
+			}
+		}
+	})
+
+	t.Run("when data is built with a DataBuilder, its keys should be rendered in insertion order", func(t *testing.T) {
+		err := NewData().
+			Set("transactionId", "tx_123456").
+			Set("userId", "67890").
+			Set("bank", "bank_123456").
+			Set("userId", "12345").
+			Attach(New("failed to complete the transaction"))
+
+		expected := "data:\n\ttransactionId: tx_123456\n\tuserId: 12345\n\tbank: bank_123456"
+		if got := dataLines(err); got != expected {
+			t.Errorf(`unexpected data output, got "%s", expected "%s"`, got, expected)
+		}
+
+		b, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		expectedJSON := `"data":{"transactionId":"tx_123456","userId":"12345","bank":"bank_123456"}`
+		if !strings.Contains(string(b), expectedJSON) {
+			t.Errorf(`expected "%s" to be in the JSON, got "%s"`, expectedJSON, b)
+		}
+	})
+
+	t.Run("when keys are missing from the order, they should be rendered after the ordered ones", func(t *testing.T) {
+		data := Data{"z": 1, "y": 2, "b": 3, "a": 4}
+
+		expected := []string{"z", "y", "a", "b"}
+		if got := data.Keys("z", "x", "y"); !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected keys, got %v, expected %v", got, expected)
+		}
+	})
+
+	t.Run("when data is built with a DataBuilder, it should only hold the keys that were set", func(t *testing.T) {
+		b := NewData().Set("b", 2).Set("a", 1)
+
+		if got := b.Build(); len(got) != 2 || !reflect.DeepEqual(got, Data{"a": 1, "b": 2}) {
+			t.Errorf("unexpected data, got %v, expected %v", got, Data{"a": 1, "b": 2})
+		}
+
+		if got := b.Keys(); !reflect.DeepEqual(got, []string{"b", "a"}) {
+			t.Errorf("unexpected keys, got %v, expected %v", got, []string{"b", "a"})
+		}
+
+		if got := fmt.Sprintf("%v", b.Build()); got != "map[a:1 b:2]" {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, "map[a:1 b:2]")
+		}
+	})
+
+	t.Run("when data built with a DataBuilder is attached to an error of another type, it should wrap it", func(t *testing.T) {
+		err := NewData().Set("b", 2).Set("a", 1).Attach(io.EOF)
+
+		e, ok := err.(*Err)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected %T", err, &Err{})
+		}
+
+		if !Is(err, io.EOF) || !reflect.DeepEqual(e.DataOrder, []string{"b", "a"}) {
+			t.Errorf("unexpected error, got %#v", e)
+		}
+
+		if frames := e.Stack.Frames(); !strings.Contains(frames[0].Function, "TestDataOrder") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestDataOrder")
+		}
+	})
+
+	t.Run("when data is marshaled, its keys should be marshaled in sorted order", func(t *testing.T) {
+		b, err := json.Marshal(Data{"b": 2, "a": 1, "c": []int{3}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `{"a":1,"b":2,"c":[3]}`
+		if string(b) != expected {
+			t.Errorf(`unexpected JSON, got "%s", expected "%s"`, b, expected)
+		}
+	})
+}
+
+func TestDataOf(t *testing.T) {
+	newChain := func() error {
+		inner := Errord(Data{"server": "db-server-01", "attempts": 3, "severity": "high"}, "connection timeout")
+		middle := fmt.Errorf("failed to query: %w", Wrapd(inner, Data{"tableName": "transactions", "severity": "low"}, "failed to update the database"))
+		return Wrapd(NewMulti(New("unrelated"), middle), Data{"userId": "67890"}, "failed to complete the transaction")
+	}
+
+	t.Run("when DataOf is provided with a chain, it should merge the data of every error with the outer values first", func(t *testing.T) {
+		expected := Data{
+			"server":    "db-server-01",
+			"attempts":  3,
+			"severity":  "low",
+			"tableName": "transactions",
+			"userId":    "67890",
+		}
+		if got := DataOf(newChain()); !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected data, got %v, expected %v", got, expected)
+		}
+	})
+
+	t.Run("when the precedence is InnerFirst, DataOf should keep the inner values", func(t *testing.T) {
+		if got := DataOf(newChain(), InnerFirst)["severity"]; got != "high" {
+			t.Errorf(`unexpected severity, got "%v", expected "%s"`, got, "high")
+		}
+	})
+
+	t.Run("when DataOf is provided with a chain without data, it should return nil", func(t *testing.T) {
+		if got := DataOf(Wrap(New("failed"), "wrapped")); got != nil {
+			t.Errorf("expected nil, got %v", got)
+		}
+	})
+
+	t.Run("when DataOf is provided with data attached by a DataBuilder, it should return the plain data", func(t *testing.T) {
+		err := NewData().Set("b", 2).Set("a", 1).Attach(New("failed"))
+
+		if got := DataOf(err); !reflect.DeepEqual(got, Data{"a": 1, "b": 2}) {
+			t.Errorf("unexpected data, got %v, expected %v", got, Data{"a": 1, "b": 2})
+		}
+	})
+}
+
+func TestLookup(t *testing.T) {
+	err := Wrapd(
+		fmt.Errorf("failed to query: %w", NewMulti(
+			New("unrelated"),
+			Errord(Data{"userId": "12345", "attempts": int64(3), "ratio": 0.5}, "connection timeout"),
+		)),
+		Data{"userId": "67890"},
+		"failed to complete the transaction",
+	)
+
+	t.Run("when Lookup is provided with a key held by multiple errors, it should return the nearest value", func(t *testing.T) {
+		if v, ok := Lookup(err, "userId"); !ok || v != "67890" {
+			t.Errorf(`unexpected value, got "%v", expected "%s"`, v, "67890")
+		}
+	})
+
+	t.Run("when Lookup is provided with a key held by an error inside a multi error, it should find it", func(t *testing.T) {
+		if v, ok := Lookup(err, "ratio"); !ok || v != 0.5 {
+			t.Errorf(`unexpected value, got "%v", expected "%v"`, v, 0.5)
+		}
+	})
+
+	t.Run("when Lookup is provided with a missing key, it should return false", func(t *testing.T) {
+		if _, ok := Lookup(err, "missing"); ok {
+			t.Errorf("expected Lookup to return false, got true")
+		}
+	})
+
+	t.Run("when LookupString is provided with a string value, it should return it", func(t *testing.T) {
+		if v, ok := LookupString(err, "userId"); !ok || v != "67890" {
+			t.Errorf(`unexpected value, got "%v", expected "%s"`, v, "67890")
+		}
+
+		if _, ok := LookupString(err, "attempts"); ok {
+			t.Errorf("expected LookupString to return false for a non-string value, got true")
+		}
+	})
+
+	t.Run("when LookupInt is provided with an integer value, it should return it", func(t *testing.T) {
+		if v, ok := LookupInt(err, "attempts"); !ok || v != 3 {
+			t.Errorf("unexpected value, got %v, expected %d", v, 3)
+		}
+
+		if _, ok := LookupInt(err, "ratio"); ok {
+			t.Errorf("expected LookupInt to return false for a fractional value, got true")
+		}
+
+		if _, ok := LookupInt(err, "userId"); ok {
+			t.Errorf("expected LookupInt to return false for a string value, got true")
+		}
+	})
+}
@@ -3,37 +3,228 @@ package errors
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
+// StackTracer is implemented by errors holding a stack trace. The %+v format, MarshalJSON, MergedStack and the log/slog
+// integration render the stack trace of any error implementing it, including the types embedding Err.
+type StackTracer interface {
+	StackTrace() Stack
+}
+
+// DataProvider is implemented by errors holding additional data. The %+v format, MarshalJSON, DataOf, Lookup and the
+// log/slog integration render and look up the data of any error implementing it, including the types embedding Err.
+type DataProvider interface {
+	ErrorData() Data
+}
+
+// Coder is implemented by errors holding a Code. CodeOf and Is read the code of any error implementing it, including
+// the types embedding Err.
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Causer is implemented by errors wrapping a cause. It matches the Unwrap method used by the standard library, so the
+// errors created with fmt.Errorf and the %w verb are causers too.
+type Causer interface {
+	Unwrap() error
+}
+
 // Err is the error struct used internally by the package. This type should only be used for type assertions.
 type Err struct {
 	Message string `json:"message"`
+	Code    Code   `json:"code,omitempty"`
 	Data    Data   `json:"data,omitempty"`
-	Stack   Stack  `json:"stack"`
-	Cause   error  `json:"cause,omitempty"`
+	// DataOrder optionally holds the order in which the keys of Data are rendered by the %+v format and MarshalJSON.
+	// The keys of Data missing from it are rendered after the ones it holds, in sorted order.
+	DataOrder []string `json:"-"`
+	Stack     Stack    `json:"stack"`
+	Cause     error    `json:"cause,omitempty"`
+
+	// origin is the error of another type to which WithCause added Cause. Its message is held by Message, and Is and
+	// As match it as well.
+	origin error
 }
 
 func (e Err) Error() string {
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s", e.Message, e.Cause.Error())
+	if isNil(e.Cause) {
+		return e.Message
+	}
+
+	if e.Message == "" {
+		return e.Cause.Error()
 	}
 
-	return e.Message
+	return fmt.Sprintf("%s: %s", e.Message, e.Cause.Error())
 }
 
-// Format implements fmt.Formatter. It only accepts the '+v' and 's' formats.
+// Format implements fmt.Formatter. The '+v' format renders e using the Formatter returned by CurrentFormatter, the
+// '#v' format renders GoString and the 'v', 's', 'q', 'x' and 'X' formats render the error message honoring the width,
+// precision and flags, just like the standard library does for any other error.
 func (e Err) Format(s fmt.State, verb rune) {
-	if verb == 'v' && s.Flag('+') {
-		fmt.Fprintf(s, "%s", format(e, 0))
-	} else {
-		fmt.Fprintf(s, "%s", e.Error())
-	}
+	formatVerb(s, verb, e, e.GoString)
+}
+
+// GoString implements fmt.GoStringer. It returns a Go-syntax representation of e and its chain, omitting empty fields,
+// redacting data and rendering stacks as their symbolized frames.
+func (e Err) GoString() string {
+	return goStringErr(e)
 }
 
 func (e Err) Unwrap() error {
 	return e.Cause
 }
 
+// As allows As to find e with a target of either type *Err or Err, so Err and *Err values in a chain are found alike.
+// A target of type *Err receives a pointer to a copy of e. Other targets are matched against the error of another type
+// to which WithCause added a cause, if any.
+func (e Err) As(target any) bool {
+	switch t := target.(type) {
+	case **Err:
+		c := e
+		*t = &c
+		return true
+	case *Err:
+		*t = e
+		return true
+	}
+
+	return e.origin != nil && As(e.origin, target)
+}
+
+// StackTrace implements StackTracer. It returns the stack trace of e.
+func (e Err) StackTrace() Stack {
+	return e.Stack
+}
+
+// ErrorData implements DataProvider. It returns the data of e.
+func (e Err) ErrorData() Data {
+	return e.Data
+}
+
+// ErrorCode implements Coder. It returns the code of e.
+func (e Err) ErrorCode() Code {
+	return e.Code
+}
+
+// Is reports whether target is an Err, *Err or Coder sharing the same non-empty code as e, or whether target matches
+// the error of another type to which WithCause added a cause, if any.
+func (e Err) Is(target error) bool {
+	if e.origin != nil && Is(e.origin, target) {
+		return true
+	}
+
+	if e.Code == "" {
+		return false
+	}
+
+	return codeOf(target) == e.Code
+}
+
+// MarshalJSON implements json.Marshaler. It is declared on *Err rather than Err, so it is not promoted to the value
+// types embedding Err, which keep their own fields when marshalled. Err values held in the chain of e are marshalled
+// like *Err values.
 func (e *Err) MarshalJSON() ([]byte, error) {
 	return json.Marshal(toMapsSlice(e))
 }
+
+// UnmarshalJSON implements json.Unmarshaler. It restores the chain of errors produced by MarshalJSON, with e holding
+// the first error of the chain and the remaining ones linked through Cause.
+func (e *Err) UnmarshalJSON(b []byte) error {
+	var entries []errEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return New("the JSON error chain is supposed to have at least one error")
+	}
+
+	if entries[0].Causes != nil {
+		return New("the JSON error chain is supposed to start with an errors.Err value")
+	}
+
+	// the first entry is restored as an Err even if it came from an error of another type
+	if entries[0].Stack == nil {
+		entries[0].Stack = json.RawMessage("null")
+	}
+
+	chain, err := fromEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	*e = *chain.(*Err)
+
+	return nil
+}
+
+// toErr returns the Err held by err if it is an Err or a non-nil *Err. Errors of other types implementing StackTracer
+// or DataProvider, such as the ones embedding Err, are described by an Err holding what they expose through
+// StackTracer, DataProvider, Coder and Causer, with the message of their cause removed from their own message. It
+// returns false for a nil *Err.
+func toErr(err error) (Err, bool) {
+	if e, ok := err.(*Err); ok {
+		if e == nil {
+			return Err{}, false
+		}
+		return withoutNilCause(*e), true
+	} else if e, ok := err.(Err); ok {
+		return withoutNilCause(e), true
+	}
+
+	st, isStackTracer := err.(StackTracer)
+	dp, isDataProvider := err.(DataProvider)
+	if !isStackTracer && !isDataProvider {
+		return Err{}, false
+	}
+
+	e := Err{Code: codeOf(err)}
+	if isStackTracer {
+		e.Stack = st.StackTrace()
+	}
+	if isDataProvider {
+		e.Data = dp.ErrorData()
+	}
+	e.Cause = unwrap(err)
+	e.Message = MessageOf(err)
+
+	return e, true
+}
+
+// withoutNilCause returns e with a nil *Err cause replaced by nil, so the renderers do not call its methods.
+func withoutNilCause(e Err) Err {
+	if isNil(e.Cause) {
+		e.Cause = nil
+	}
+	return e
+}
+
+// MessageOf returns the message of err without the message of its cause, which is the Message of an Err or *Err. For
+// errors of other types, the message of the error returned by their Unwrap method is removed from the end of their
+// own message along with the colon separating them, as in Err.Error. It returns an empty string if err is nil.
+func MessageOf(err error) string {
+	if e, ok := err.(*Err); ok {
+		if e == nil {
+			return ""
+		}
+		return e.Message
+	} else if e, ok := err.(Err); ok {
+		return e.Message
+	} else if err == nil {
+		return ""
+	}
+
+	msg := err.Error()
+	cause := unwrap(err)
+	if cause == nil {
+		return msg
+	}
+
+	causeMsg := cause.Error()
+	if msg == causeMsg {
+		return ""
+	}
+
+	return strings.TrimSuffix(msg, ": "+causeMsg)
+}
@@ -1,8 +1,13 @@
 package errors
 
 import (
+	"bytes"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -102,4 +107,495 @@ func TestJSONMarshaling(t *testing.T) {
 			t.Errorf("unexpected error message, got %q, expected %q", errs[2]["message"], err1.(*Err).Message)
 		}
 	})
+
+	t.Run("when marshaling a chain mixing Err and *Err values, should marshal every one of them alike", func(t *testing.T) {
+		err1 := Err{Message: "context timeout", Code: "DB_TIMEOUT", Data: Data{"server": "db-server-01"}, Stack: Callers(0)}
+		err2 := fmt.Errorf("failed to connect to the database: %w", err1)
+		err3 := Err{Message: "failed to query", Data: Data{"query": "SELECT 1"}, Stack: Callers(0), Cause: err2}
+		err4 := Wrap(err3, "failed to start the server")
+
+		for _, marshaled := range []any{err4, &err3} {
+			b, err := json.Marshal(marshaled)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var errs []map[string]any
+			if err := json.Unmarshal(b, &errs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the chain always ends with err3, err2 and err1
+			errs = errs[len(errs)-3:]
+
+			if errs[0]["message"] != err3.Message || errs[2]["message"] != err1.Message {
+				t.Errorf("unexpected errors for %T, got %v", marshaled, errs)
+			}
+
+			if data, _ := errs[0]["data"].(map[string]any); data["query"] != "SELECT 1" {
+				t.Errorf("unexpected data for %T, got %v", marshaled, errs[0]["data"])
+			}
+
+			if data, _ := errs[2]["data"].(map[string]any); data["server"] != "db-server-01" || errs[2]["code"] != "DB_TIMEOUT" {
+				t.Errorf("unexpected data for %T, got %v", marshaled, errs[2])
+			}
+
+			for _, i := range []int{0, 2} {
+				if stack, _ := errs[i]["stack"].([]any); len(stack) == 0 {
+					t.Errorf("expected the stack of error %d to be marshaled for %T, got %v", i, marshaled, errs[i]["stack"])
+				}
+			}
+		}
+	})
+
+	t.Run("when marshaling a struct embedding an Err value, should keep the fields of the struct", func(t *testing.T) {
+		b, err := json.Marshal(customError4{Err: Err{Message: "context timeout"}, Op: "write"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `{"message":"context timeout","stack":null,"Op":"write"}`
+		if string(b) != expected {
+			t.Errorf(`unexpected JSON, got "%s", expected "%s"`, b, expected)
+		}
+	})
+
+	t.Run("when a chain mixing Err and *Err values is marshaled, FromJSON should restore it as *Err values", func(t *testing.T) {
+		err := Wrap(Err{Message: "context timeout", Data: Data{"id": 1}, Stack: Callers(0)}, "failed to start the server")
+
+		b, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		restored, jsonErr := FromJSON(b)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		cause, ok := restored.Cause.(*Err)
+		if !ok {
+			t.Fatalf("unexpected cause type, got %T, expected %T", restored.Cause, &Err{})
+		}
+
+		if cause.Message != "context timeout" || !reflect.DeepEqual(cause.Data, Data{"id": float64(1)}) {
+			t.Errorf("unexpected cause, got %#v", cause)
+		}
+	})
+}
+
+func TestJSONUnmarshaling(t *testing.T) {
+	t.Run("when unmarshaling a marshaled chain of errors.Err errors, should restore the full chain", func(t *testing.T) {
+		err1 := New("context timeout")
+		err2 := Wrap(err1, "failed to connect to the database")
+		err3 := Wrapd(err2, Data{
+			"server": "db-server-01",
+		}, "failed to start the server")
+
+		b, err := json.Marshal(err3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		restored, err := FromJSON(b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if restored.Error() != err3.Error() {
+			t.Errorf("unexpected error message, got %q, expected %q", restored.Error(), err3.Error())
+		}
+
+		if !reflect.DeepEqual(restored.Data, Data{"server": "db-server-01"}) {
+			t.Errorf("unexpected data, got %v, expected %v", restored.Data, err3.(*Err).Data)
+		}
+
+		if !reflect.DeepEqual(restored.Stack.Strings(), err3.(*Err).Stack.Strings()) {
+			t.Errorf("unexpected stack, got %v, expected %v", restored.Stack.Strings(), err3.(*Err).Stack.Strings())
+		}
+
+		frame := restored.Stack.Frames()[0]
+		expectedFrame := err3.(*Err).Stack.Frames()[0]
+		if frame.Function != expectedFrame.Function || frame.File != expectedFrame.File || frame.Line != expectedFrame.Line {
+			t.Errorf("unexpected frame, got %+v, expected %+v", frame, expectedFrame)
+		}
+
+		restored2, ok := restored.Cause.(*Err)
+		if !ok {
+			t.Fatalf("unexpected cause type, got %T, expected *Err", restored.Cause)
+		}
+
+		if restored2.Message != err2.(*Err).Message {
+			t.Errorf("unexpected error message, got %q, expected %q", restored2.Message, err2.(*Err).Message)
+		}
+
+		restored1, ok := restored2.Cause.(*Err)
+		if !ok {
+			t.Fatalf("unexpected cause type, got %T, expected *Err", restored2.Cause)
+		}
+
+		if restored1.Cause != nil {
+			t.Errorf("unexpected cause, got %v, expected nil", restored1.Cause)
+		}
+
+		b2, err := json.Marshal(restored)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(b2) != string(b) {
+			t.Errorf("unexpected JSON after the round-trip, got %s, expected %s", b2, b)
+		}
+	})
+
+	t.Run("when unmarshaling a marshaled chain of errors.Err and standard errors, should restore the standard errors with their messages", func(t *testing.T) {
+		err1 := New("context timeout")
+		err2 := fmt.Errorf("failed to connect to the database: %w", err1)
+		err3 := Wrap(err2, "failed to start the server")
+
+		b, err := json.Marshal(err3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var restored Err
+		if err := json.Unmarshal(b, &restored); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if restored.Error() != err3.Error() {
+			t.Errorf("unexpected error message, got %q, expected %q", restored.Error(), err3.Error())
+		}
+
+		if _, ok := restored.Cause.(*Err); ok {
+			t.Errorf("unexpected cause type, got *Err, expected a standard error")
+		}
+
+		if restored.Cause.Error() != err2.Error() {
+			t.Errorf("unexpected error message, got %q, expected %q", restored.Cause.Error(), err2.Error())
+		}
+
+		var restored1 *Err
+		if !As(Unwrap(restored.Cause), &restored1) || restored1.Message != err1.(*Err).Message {
+			t.Errorf("unexpected innermost error, got %v, expected %v", Unwrap(restored.Cause), err1)
+		}
+
+		b2, err := json.Marshal(&restored)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(b2) != string(b) {
+			t.Errorf("unexpected JSON after the round-trip, got %s, expected %s", b2, b)
+		}
+	})
+
+	t.Run("when unmarshaling an empty chain, should return an error", func(t *testing.T) {
+		if _, err := FromJSON([]byte("[]")); err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+
+	t.Run("when unmarshaling an invalid JSON, should return an error", func(t *testing.T) {
+		if _, err := FromJSON([]byte(`{"message": "not a chain"}`)); err == nil {
+			t.Errorf("expected an error, got nil")
+		}
+	})
+}
+
+func TestErrFormat(t *testing.T) {
+	err := &Err{
+		Message: "failed to complete the transaction",
+		Code:    "TX_FAILED",
+		Data:    Data{"userId": "67890", "attempts": 3, "token": NewSecret("abc")},
+		Stack:   ParseStack([]string{"main.main @ /app/main.go:10"}),
+		Cause:   &Err{Message: "connection timeout", Cause: stderrors.New("network unreachable")},
+	}
+	msg := "failed to complete the transaction: connection timeout: network unreachable"
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", msg},
+		{"%s", msg},
+		{"%q", `"` + msg + `"`},
+		{"%+q", `"` + msg + `"`},
+		{"%#q", "`" + msg + "`"},
+		{"%x", fmt.Sprintf("%x", msg)},
+		{"%X", fmt.Sprintf("%X", msg)},
+		{"% x", fmt.Sprintf("% x", msg)},
+		{"%.6s", "failed"},
+		{"%90s", fmt.Sprintf("%90s", msg)},
+		{"%-90s|", fmt.Sprintf("%-90s|", msg)},
+		{"%-90v|", fmt.Sprintf("%-90v|", msg)},
+		{"%10.6v|", "    failed|"},
+		{"%-10.6q|", `"failed"  |`},
+		{"%d", "%!d(errors.Err=" + msg + ")"},
+		{"%#v", `errors.Err{Message:"failed to complete the transaction", Code:"TX_FAILED", ` +
+			`Data:errors.Data{"attempts":3, "token":"[REDACTED]", "userId":"67890"}, ` +
+			`Stack:errors.Stack{"main.main @ /app/main.go:10"}, ` +
+			`Cause:errors.Err{Message:"connection timeout", Cause:&errors.errorString{s:"network unreachable"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("when an error is formatted with %q, it should follow the standard library conventions", tt.format), func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); got != tt.expected {
+				t.Errorf(`unexpected output, got "%s", expected "%s"`, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("when an error value is formatted with %#v, it should render the same as a pointer", func(t *testing.T) {
+		if got, expected := fmt.Sprintf("%#v", *err), fmt.Sprintf("%#v", err); got != expected {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, expected)
+		}
+	})
+
+	t.Run("when an error value or a struct embedding one is formatted with %+v, it should render the same as a pointer", func(t *testing.T) {
+		expected := fmt.Sprintf("%+v", err)
+
+		values := map[string]any{
+			"Err":         *err,
+			"composition": valueComposition{Err: *err, ID: 1},
+		}
+		for name, value := range values {
+			if got := fmt.Sprintf("%+v", value); got != expected {
+				t.Errorf(`unexpected output for %s, got "%s", expected "%s"`, name, got, expected)
+			}
+		}
+
+		expected = "data:\n\tattempts: 3\n\ttoken: " + RedactedValue
+		if outputStr := fmt.Sprintf("%+v", WithStack(*err)); !strings.Contains(outputStr, expected) {
+			t.Errorf(`expected "%s" to be in the output string, got "%v"`, expected, outputStr)
+		}
+	})
+}
+
+// valueComposition is a custom error type composed with a value Err and used as a value.
+type valueComposition struct {
+	Err
+	ID int
+}
+
+// domainError is a custom error type not composed with Err, exposing its data, stack and cause through the
+// StackTracer, DataProvider, Coder and Causer interfaces.
+type domainError struct {
+	op    string
+	data  Data
+	stack Stack
+	cause error
+}
+
+func (e domainError) Error() string     { return e.op + ": " + e.cause.Error() }
+func (e domainError) StackTrace() Stack { return e.stack }
+func (e domainError) ErrorData() Data   { return e.data }
+func (e domainError) ErrorCode() Code   { return "DOMAIN" }
+func (e domainError) Unwrap() error     { return e.cause }
+
+func TestErrInterfaces(t *testing.T) {
+	newDomainError := func() error {
+		return Wrap(domainError{
+			op:    "failed to charge the card",
+			data:  Data{"cardId": "1234"},
+			stack: ParseStack([]string{"main.charge @ /app/charge.go:42"}),
+			cause: &Err{Message: "insufficient funds"},
+		}, "failed to complete the order")
+	}
+
+	t.Run("when a chain holds a custom error implementing the interfaces, %+v should render it fully", func(t *testing.T) {
+		outputStr := fmt.Sprintf("%+v", newDomainError())
+
+		expected := []string{
+			"\tmessage:\n\t\t\"failed to charge the card\"",
+			"\tcode:\n\t\tDOMAIN",
+			"\tdata:\n\t\tcardId: 1234",
+			"\tstack:\n\t\tmain.charge @ /app/charge.go:42",
+			"\tcause:\n\t\tmessage:\n\t\t\t\"insufficient funds\"",
+		}
+		for _, e := range expected {
+			if !strings.Contains(outputStr, e) {
+				t.Errorf(`expected "%s" to be in the output string, got "%v"`, e, outputStr)
+			}
+		}
+	})
+
+	t.Run("when a chain holds a custom error implementing the interfaces, MarshalJSON should marshal it fully", func(t *testing.T) {
+		b, err := json.Marshal(newDomainError())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var errs []map[string]any
+		if err := json.Unmarshal(b, &errs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(errs) != 3 {
+			t.Fatalf("unexpected number of errors, got %d, expected %d", len(errs), 3)
+		}
+
+		if errs[1]["message"] != "failed to charge the card" || errs[1]["code"] != "DOMAIN" {
+			t.Errorf("unexpected error, got %v", errs[1])
+		}
+
+		if data, _ := errs[1]["data"].(map[string]any); data["cardId"] != "1234" {
+			t.Errorf("unexpected data, got %v", errs[1]["data"])
+		}
+
+		if stack, _ := errs[1]["stack"].([]any); len(stack) != 1 || stack[0] != "main.charge @ /app/charge.go:42" {
+			t.Errorf("unexpected stack, got %v", errs[1]["stack"])
+		}
+	})
+
+	t.Run("when a chain holds a custom error implementing the interfaces, its data and code should be looked up", func(t *testing.T) {
+		err := newDomainError()
+
+		if got, _ := LookupString(err, "cardId"); got != "1234" {
+			t.Errorf(`unexpected data, got "%v", expected "%v"`, got, "1234")
+		}
+
+		if got := CodeOf(err); got != "DOMAIN" {
+			t.Errorf(`unexpected code, got "%v", expected "%v"`, got, "DOMAIN")
+		}
+	})
+
+	t.Run("when a chain holds a composition of Err as a cause, %+v should render it fully", func(t *testing.T) {
+		defer SetStackCapture(StackCaptureEnabled())
+		SetStackCapture(false)
+
+		composition := CustomError{Err: &Err{Message: "custom error", Data: Data{"id": 1}, Cause: New("inner error")}}
+		outputStr := fmt.Sprintf("%+v", Wrap(composition, "outer error"))
+
+		expected := "cause:\n\tmessage:\n\t\t\"custom error\"\n\tdata:\n\t\tid: 1\n\tcause:\n\t\tmessage:\n\t\t\t\"inner error\""
+		if !strings.Contains(outputStr, expected) {
+			t.Errorf(`expected "%s" to be in the output string, got "%v"`, expected, outputStr)
+		}
+	})
+}
+
+func TestErrValues(t *testing.T) {
+	t.Run("when a chain holds an Err value, As should find it with a *Err target", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", Err{Message: "value error", Code: "VALUE"})
+
+		var target *Err
+		if !As(err, &target) {
+			t.Fatal("expected As to return true, got false")
+		}
+
+		if target.Message != "value error" || target.Code != "VALUE" {
+			t.Errorf("unexpected target, got %#v", target)
+		}
+	})
+
+	t.Run("when a chain holds a *Err, As should find it with an Err target", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", &Err{Message: "pointer error"})
+
+		var target Err
+		if !As(err, &target) {
+			t.Fatal("expected As to return true, got false")
+		}
+
+		if target.Message != "pointer error" {
+			t.Errorf("unexpected target, got %#v", target)
+		}
+	})
+
+	t.Run("when a chain holds an Err value, %+v, WithStack and the data lookup should handle it like a *Err", func(t *testing.T) {
+		defer SetStackCapture(StackCaptureEnabled())
+		SetStackCapture(false)
+
+		value := Err{Message: "value error", Data: Data{"id": 1}}
+		err := Wrap(value, "outer error")
+
+		expected := "cause:\n\tmessage:\n\t\t\"value error\"\n\tdata:\n\t\tid: 1"
+		if outputStr := fmt.Sprintf("%+v", err); !strings.Contains(outputStr, expected) {
+			t.Errorf(`expected "%s" to be in the output string, got "%v"`, expected, outputStr)
+		}
+
+		if got, _ := LookupInt(err, "id"); got != 1 {
+			t.Errorf("unexpected data, got %v, expected %v", got, 1)
+		}
+
+		if _, ok := WithStack(value).(Err); !ok {
+			t.Errorf("unexpected error type, got %T, expected %T", WithStack(value), value)
+		}
+	})
+}
+
+func TestMessageOf(t *testing.T) {
+	t.Run("when MessageOf is provided with errors of any type, it should return their message without their cause", func(t *testing.T) {
+		inner := New("context timeout")
+
+		tests := []struct {
+			err      error
+			expected string
+		}{
+			{Wrap(inner, "failed to query"), "failed to query"},
+			{Err{Message: "failed to query", Cause: inner}, "failed to query"},
+			{WithStack(inner), "context timeout"},
+			{fmt.Errorf("failed to query: %w", inner), "failed to query"},
+			{fmt.Errorf("%w", inner), ""},
+			{stderrors.New("failed to query"), "failed to query"},
+			{(*Err)(nil), ""},
+			{nil, ""},
+		}
+
+		for _, tt := range tests {
+			if got := MessageOf(tt.err); got != tt.expected {
+				t.Errorf(`unexpected message for "%v", got "%s", expected "%s"`, tt.err, got, tt.expected)
+			}
+		}
+	})
+}
+
+func TestNilErrCause(t *testing.T) {
+	newErrs := func() map[string]error {
+		return map[string]error{
+			"Wrap":      Wrap((*Err)(nil), "outer"),
+			"WithStack": WithStack((*Err)(nil)),
+			"Err value": Err{Message: "outer", Cause: (*Err)(nil)},
+			"multi":     NewMulti(New("outer"), (*Err)(nil)),
+		}
+	}
+
+	t.Run("when a chain ends with a nil *Err, every rendering should treat it as the end of the chain", func(t *testing.T) {
+		for name, err := range newErrs() {
+			if _, jsonErr := json.Marshal(err); jsonErr != nil {
+				t.Errorf("unexpected error for %s: %v", name, jsonErr)
+			}
+
+			var buf bytes.Buffer
+			slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil))).Error("failed", "error", err)
+
+			renderings := []string{fmt.Sprintf("%+v", err), fmt.Sprintf("%#v", err), buf.String()}
+			for _, f := range []Formatter{CompactFormatter, LogfmtFormatter, MarkdownFormatter} {
+				renderings = append(renderings, FormatWith(err, f))
+			}
+
+			for _, output := range renderings {
+				if strings.Contains(output, "PANIC") {
+					t.Errorf(`unexpected panic for %s, got "%s"`, name, output)
+				}
+			}
+		}
+	})
+
+	t.Run("when a chain ends with a nil *Err, the lookups should stop at it", func(t *testing.T) {
+		for name, err := range newErrs() {
+			if got := DataOf(err); got != nil {
+				t.Errorf("unexpected data for %s, got %v", name, got)
+			}
+
+			if got := CodeOf(err); got != "" {
+				t.Errorf(`unexpected code for %s, got "%s"`, name, got)
+			}
+
+			MergedStack(err)
+		}
+
+		if got := Wrap((*Err)(nil), "outer").Error(); got != "outer" {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, got, "outer")
+		}
+	})
 }
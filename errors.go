@@ -1,12 +1,11 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 )
 
-type Data map[string]any
-
 // New returns an error with the provided message.
 func New(msg string) error {
 	return &Err{
@@ -41,6 +40,24 @@ func Errordf(data Data, format string, args ...any) error {
 	}
 }
 
+// NewCode returns an error with the provided code and message.
+func NewCode(code Code, msg string) error {
+	return &Err{
+		Message: msg,
+		Code:    code,
+		Stack:   callers(),
+	}
+}
+
+// NewCodef returns an error with the provided code and format specifier.
+func NewCodef(code Code, format string, args ...any) error {
+	return &Err{
+		Message: fmt.Sprintf(format, args...),
+		Code:    code,
+		Stack:   callers(),
+	}
+}
+
 // Wrap returns an error wrapping err and adding the provided format specifier.
 func Wrap(err error, msg string) error {
 	return &Err{
@@ -79,30 +96,177 @@ func Wrapdf(err error, data Data, format string, args ...any) error {
 	}
 }
 
-// WithStack adds a stack trace to the provided error if it is an Err or *Err.
+// WrapCode returns an error wrapping err and adding the provided code and message.
+func WrapCode(err error, code Code, msg string) error {
+	return &Err{
+		Message: msg,
+		Code:    code,
+		Stack:   callers(),
+		Cause:   err,
+	}
+}
+
+// WrapCodef returns an error wrapping err and adding the provided code and format specifier.
+func WrapCodef(err error, code Code, format string, args ...any) error {
+	return &Err{
+		Message: fmt.Sprintf(format, args...),
+		Code:    code,
+		Stack:   callers(),
+		Cause:   err,
+	}
+}
+
+// FromJSON restores a chain of errors marshalled by Err.MarshalJSON, e.g. one received from another process. Errors
+// of the chain that were not of the type Err are restored as errors holding their original message.
+func FromJSON(b []byte) (*Err, error) {
+	var e Err
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// WithStack returns a copy of the provided error with a stack trace if it is an Err, *Err or a composition of them.
+// Errors of other types are wrapped by a new *Err holding the stack trace and no message of its own, so its Error
+// method returns the message of err and Unwrap returns err. The provided error is never modified, so it is safe to
+// call WithStack on errors shared between goroutines.
 func WithStack(err error) error {
-	if e, ok := err.(Err); ok {
-		e.Stack = callers()
-		return e
-	} else if e, ok := err.(*Err); ok {
-		e.Stack = callers()
+	if err == nil {
+		return nil
+	}
+
+	return withStack(err, callers())
+}
+
+// WithStackSkip behaves like WithStack, skipping skip frames above the caller of WithStackSkip. It allows helper
+// functions to attach a stack trace pointing at their own callers.
+func WithStackSkip(err error, skip int) error {
+	if err == nil {
+		return nil
+	}
+
+	return withStack(err, callersSkip(skip+3))
+}
+
+// withStack implements WithStack and WithStackSkip for a non-nil err and the stack trace captured by them.
+func withStack(err error, stack Stack) error {
+	if e, ok := withErr(err, func(e *Err) { e.Stack = stack }); ok {
 		return e
-	} else {
-		return err
+	}
+
+	return &Err{
+		Stack: stack,
+		Cause: err,
 	}
 }
 
-// WithCause adds a cause to the provided error if it is an Err or *Err.
+// WithCause returns a copy of the provided error with a cause if it is an Err, *Err or a composition of them. Errors
+// of other types are replaced by a new *Err with a stack trace holding the message of err and cause, for which Is and
+// As match both err and cause. The provided error is never modified.
 func WithCause(err error, cause error) error {
-	if e, ok := err.(Err); ok {
-		e.Cause = cause
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := withErr(err, func(e *Err) { e.Cause = cause }); ok {
 		return e
-	} else if e, ok := err.(*Err); ok {
-		e.Cause = cause
+	}
+
+	return &Err{
+		Message: err.Error(),
+		Stack:   callers(),
+		Cause:   cause,
+		origin:  err,
+	}
+}
+
+// WithData returns a copy of the provided error with data merged into its own if it is an Err, *Err or a composition
+// of them, with the values of data taking precedence. Errors of other types are wrapped by a new *Err holding data and
+// no message of its own, so its Error method returns the message of err. The provided error is never modified.
+func WithData(err error, data Data) error {
+	return withData(err, data, nil, 4)
+}
+
+// withData implements WithData, additionally appending order to the Err.DataOrder of err. Errors of other types are
+// wrapped by a new *Err with a stack trace skipping skip frames, as in runtime.Callers.
+func withData(err error, data Data, order []string, skip int) error {
+	if err == nil {
+		return nil
+	}
+
+	if e, ok := withErr(err, func(e *Err) {
+		e.Data = mergeData(e.Data, data)
+		e.DataOrder = mergeOrder(e.DataOrder, order)
+	}); ok {
 		return e
-	} else {
-		return err
 	}
+
+	return &Err{
+		Data:      data,
+		DataOrder: order,
+		Stack:     callersSkip(skip),
+		Cause:     err,
+	}
+}
+
+// withErr returns a copy of err with fn applied to its Err if it is an Err, *Err or a composition of them holding an
+// exported Err or *Err field, such as an embedded one. It returns false if err holds no Err that can be modified.
+func withErr(err error, fn func(e *Err)) (error, bool) {
+	if e, ok := err.(Err); ok {
+		fn(&e)
+		return e, true
+	} else if e, ok := err.(*Err); ok && e != nil {
+		c := *e
+		fn(&c)
+		return &c, true
+	}
+
+	v := reflect.ValueOf(err)
+	isPointer := v.Kind() == reflect.Pointer
+	if isPointer {
+		if v.IsNil() {
+			return err, false
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return err, false
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	for i := 0; i < c.NumField(); i++ {
+		field := c.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		switch field.Type() {
+		case reflect.TypeOf(Err{}):
+			e := field.Interface().(Err)
+			fn(&e)
+			field.Set(reflect.ValueOf(e))
+		case reflect.TypeOf((*Err)(nil)):
+			var e Err
+			if !field.IsNil() {
+				e = *field.Interface().(*Err)
+			}
+			fn(&e)
+			field.Set(reflect.ValueOf(&e))
+		default:
+			continue
+		}
+
+		if isPointer {
+			return c.Addr().Interface().(error), true
+		}
+		return c.Interface().(error), true
+	}
+
+	return err, false
 }
 
 // IsErrComposition returns true if the provided error is a composition of Err or *Err.
@@ -129,3 +293,44 @@ func IsErrComposition(err error) bool {
 
 	return false
 }
+
+// walk calls fn for err and every error in its chain, in depth-first order, until fn returns false. Errors wrapping
+// multiple errors, such as MultiError, have every one of them walked. A nil *Err ends the chain like a nil error.
+func walk(err error, fn func(error) bool) bool {
+	if isNil(err) {
+		return true
+	}
+
+	if !fn(err) {
+		return false
+	}
+
+	if multi, ok := err.(multiUnwrapper); ok {
+		for _, child := range multi.Unwrap() {
+			if !walk(child, fn) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return walk(unwrap(err), fn)
+}
+
+// isNil reports whether err is nil or a nil *Err, whose value methods panic when called.
+func isNil(err error) bool {
+	e, ok := err.(*Err)
+	return err == nil || ok && e == nil
+}
+
+// unwrap returns the result of Unwrap for err, or nil if either err or the error it wraps is a nil *Err.
+func unwrap(err error) error {
+	if isNil(err) {
+		return nil
+	}
+
+	if cause := Unwrap(err); !isNil(cause) {
+		return cause
+	}
+	return nil
+}
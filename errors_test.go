@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -15,9 +16,7 @@ type CustomError struct {
 
 // NewCustomError returns a new CustomError and adds a stack trace.
 func NewCustomError(message string) error {
-	customError := CustomError{Err: &Err{Message: message}}
-	WithStack(customError.Err)
-	return customError
+	return CustomError{Err: WithStack(&Err{Message: message}).(*Err)}
 }
 
 // CustomError2 is a custom error type composed with Err.
@@ -27,9 +26,13 @@ type CustomError2 struct {
 
 // NewCustom2Error returns a new CustomError2 and adds a cause to the error.
 func NewCustom2Error(message string, cause error) error {
-	customError2 := CustomError2{Err: &Err{Message: message}}
-	WithCause(customError2.Err, cause)
-	return customError2
+	return CustomError2{Err: WithCause(&Err{Message: message}, cause).(*Err)}
+}
+
+// customError4 is a custom error type composed with a value Err, used through a pointer.
+type customError4 struct {
+	Err
+	Op string
 }
 
 // customError3 is a custom error type not composed with Err.
@@ -154,6 +157,86 @@ func TestWithStack(t *testing.T) {
 			t.Errorf(`expected "stack:" to be in the output string, got "%v"`, outputStr)
 		}
 	})
+
+	t.Run("when WithStack is provided with a *Err, it should not modify it", func(t *testing.T) {
+		err := &Err{Message: "error message"}
+
+		got := WithStack(err)
+		if got == error(err) {
+			t.Fatal("expected a new error, got the provided one")
+		}
+
+		if err.Stack != nil {
+			t.Errorf("expected the provided error to have no stack, got %v", err.Stack)
+		}
+
+		if got.(*Err).Stack == nil {
+			t.Errorf("expected the returned error to have a stack, got nil")
+		}
+	})
+
+	t.Run("when WithStack is provided with an error of another type, it should wrap it with a stack trace", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+
+		got, ok := WithStack(stdErr).(*Err)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected %T", got, &Err{})
+		}
+
+		if got.Error() != stdErr.Error() {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, got.Error(), stdErr.Error())
+		}
+
+		if Unwrap(got) != stdErr {
+			t.Errorf(`expected the wrapped error to be "%v", got "%v"`, stdErr, Unwrap(got))
+		}
+
+		if frames := got.Stack.Frames(); !strings.Contains(frames[0].Function, "TestWithStack") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestWithStack")
+		}
+	})
+
+	t.Run("when WithStack is provided with a composition of Err, it should return a copy with a stack trace", func(t *testing.T) {
+		valueErr := CustomError{Err: &Err{Message: "value composition"}}
+
+		got, ok := WithStack(valueErr).(CustomError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected %T", got, valueErr)
+		}
+
+		if got.Stack == nil || got.Message != "value composition" {
+			t.Errorf("expected the returned error to have the message and a stack, got %#v", got.Err)
+		}
+
+		if valueErr.Stack != nil {
+			t.Errorf("expected the provided error to have no stack, got %v", valueErr.Stack)
+		}
+
+		pointerErr := &customError4{Err: Err{Message: "pointer composition"}, Op: "read"}
+
+		gotPointer, ok := WithStack(pointerErr).(*customError4)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected %T", gotPointer, pointerErr)
+		}
+
+		if gotPointer == pointerErr {
+			t.Fatal("expected a new error, got the provided one")
+		}
+
+		if gotPointer.Stack == nil || gotPointer.Op != "read" {
+			t.Errorf("expected the returned error to have the fields and a stack, got %#v", gotPointer)
+		}
+
+		if pointerErr.Stack != nil {
+			t.Errorf("expected the provided error to have no stack, got %v", pointerErr.Stack)
+		}
+	})
+
+	t.Run("when WithStack is provided with nil, it should return nil", func(t *testing.T) {
+		if err := WithStack(nil); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
 }
 
 func TestWithCause(t *testing.T) {
@@ -165,6 +248,148 @@ func TestWithCause(t *testing.T) {
 			t.Errorf(`expected cause to be "%v", got "%v"`, causeErr, err.(CustomError2).Cause)
 		}
 	})
+
+	t.Run("when WithCause is provided with a *Err, it should not modify it", func(t *testing.T) {
+		err := &Err{Message: "outer error"}
+
+		got := WithCause(err, New("inner error"))
+		if got == error(err) {
+			t.Fatal("expected a new error, got the provided one")
+		}
+
+		if err.Cause != nil {
+			t.Errorf("expected the provided error to have no cause, got %v", err.Cause)
+		}
+
+		if got.(*Err).Cause == nil {
+			t.Errorf("expected the returned error to have a cause, got nil")
+		}
+	})
+
+	t.Run("when WithCause is provided with an error of another type, Is should match both the error and the cause", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+		causeErr := NewCode("DB_TIMEOUT", "connection timeout")
+
+		got := WithCause(stdErr, causeErr)
+		if _, ok := got.(*Err); !ok {
+			t.Fatalf("unexpected error type, got %T, expected %T", got, &Err{})
+		}
+
+		expected := "standard error: connection timeout"
+		if got.Error() != expected {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, got.Error(), expected)
+		}
+
+		if !Is(got, stdErr) || !Is(got, causeErr) {
+			t.Errorf("expected Is to match both the error and the cause")
+		}
+	})
+
+	t.Run("when WithCause is provided with an error of another type, %+v should render the cause under the error", func(t *testing.T) {
+		got := WithCause(customErr{msg: "standard error"}, NewCode("DB_TIMEOUT", "connection timeout"))
+
+		expected := "cause:\n\tmessage:\n\t\t\"connection timeout\"\n\tcode:\n\t\tDB_TIMEOUT"
+		if outputStr := fmt.Sprintf("%+v", got); !strings.HasPrefix(outputStr, "message:\n\t\"standard error\"") ||
+			!strings.Contains(outputStr, expected) {
+			t.Errorf(`expected "%s" to be in the output string, got "%v"`, expected, outputStr)
+		}
+
+		var target customErr
+		if !As(got, &target) || target.msg != "standard error" {
+			t.Errorf(`unexpected target, got "%v", expected "%s"`, target, "standard error")
+		}
+	})
+
+	t.Run("when WithCause is provided with a composition of Err, it should return a copy with the cause", func(t *testing.T) {
+		causeErr := New("inner error")
+		err := &customError4{Err: Err{Message: "outer error"}, Op: "write"}
+
+		got := WithCause(err, causeErr).(*customError4)
+		if got.Cause != causeErr || got.Op != "write" {
+			t.Errorf("expected the returned error to have the fields and the cause, got %#v", got)
+		}
+
+		if err.Cause != nil {
+			t.Errorf("expected the provided error to have no cause, got %v", err.Cause)
+		}
+
+		if !Is(got, causeErr) {
+			t.Errorf("expected Is to return true, got false")
+		}
+	})
+}
+
+func TestWithData(t *testing.T) {
+	t.Run("when WithData is provided with a *Err, it should return a copy with the data merged", func(t *testing.T) {
+		err := Errord(Data{"id": 1, "server": "db-server-01"}, "failed").(*Err)
+
+		got := WithData(err, Data{"id": 2, "attempts": 3}).(*Err)
+
+		expected := Data{"id": 2, "server": "db-server-01", "attempts": 3}
+		if !reflect.DeepEqual(got.Data, expected) {
+			t.Errorf("unexpected data, got %v, expected %v", got.Data, expected)
+		}
+
+		if !reflect.DeepEqual(err.Data, Data{"id": 1, "server": "db-server-01"}) {
+			t.Errorf("expected the provided error to be unchanged, got %v", err.Data)
+		}
+	})
+
+	t.Run("when WithData is provided with an error holding a data order, it should keep the insertion order", func(t *testing.T) {
+		err := NewData().Set("b", 1).Set("a", 2).Attach(New("failed"))
+
+		got := WithData(err, Data{"d": 4, "c": 3}).(*Err)
+		if keys := got.Data.Keys(got.DataOrder...); !reflect.DeepEqual(keys, []string{"b", "a", "c", "d"}) {
+			t.Errorf("unexpected keys, got %v, expected %v", keys, []string{"b", "a", "c", "d"})
+		}
+	})
+
+	t.Run("when WithData is provided with an error of another type, it should wrap it", func(t *testing.T) {
+		stdErr := errors.New("standard error")
+
+		got := WithData(stdErr, Data{"id": 1})
+		if got.Error() != stdErr.Error() {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, got.Error(), stdErr.Error())
+		}
+
+		if !Is(got, stdErr) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if id, _ := LookupInt(got, "id"); id != 1 {
+			t.Errorf("unexpected data, got %v, expected %v", id, 1)
+		}
+	})
+}
+
+func TestWithConcurrency(t *testing.T) {
+	t.Run("when a shared *Err is used by multiple goroutines, the With functions should not race", func(t *testing.T) {
+		shared := Errord(Data{"id": 1}, "shared error")
+		keyAttempt := NewKey[int]("attempt")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 16; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				err := WithStack(shared)
+				err = WithCause(err, New("cause"))
+				err = WithData(err, Data{"goroutine": i})
+				err = With(err, keyAttempt, i)
+				_ = fmt.Sprintf("%+v %v", shared, err)
+
+				if got, _ := keyAttempt.From(err); got != i {
+					t.Errorf("unexpected attempt, got %d, expected %d", got, i)
+				}
+			}(i)
+		}
+		wg.Wait()
+
+		if e := shared.(*Err); e.Cause != nil || len(e.Data) != 1 {
+			t.Errorf("expected the shared error to be unchanged, got %#v", e)
+		}
+	})
 }
 
 func TestIsErrComposition(t *testing.T) {
@@ -3,40 +3,123 @@ package errors
 import (
 	"bytes"
 	"fmt"
-	"reflect"
+	"io"
 	"strings"
 )
 
+// formatVerb implements fmt.Formatter for err following the conventions of the standard library. The '+v' format
+// renders err using the Formatter returned by CurrentFormatter, the '#v' format renders goString, the 'v', 's', 'q',
+// 'x' and 'X' formats render err.Error() honoring the width, precision and flags, and any other verb is reported as a
+// bad verb.
+func formatVerb(s fmt.State, verb rune, err error, goString func() string) {
+	switch {
+	case verb == 'v' && s.Flag('+'):
+		io.WriteString(s, CurrentFormatter().Format(err))
+	case verb == 'v' && s.Flag('#'):
+		io.WriteString(s, goString())
+	case verb == 'v' || verb == 's' || verb == 'q' || verb == 'x' || verb == 'X':
+		fmt.Fprintf(s, fmt.FormatString(s, verb), err.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(%T=%s)", verb, err, err.Error())
+	}
+}
+
+// goString returns a Go-syntax representation of err. Err and MultiError values and pointers are rendered alike by
+// goStringErr and goStringMulti, and errors of other types are rendered by the %#v format.
+func goString(err error) string {
+	switch e := err.(type) {
+	case Err:
+		return goStringErr(e)
+	case *Err:
+		if e == nil {
+			return "(*errors.Err)(nil)"
+		}
+		return goStringErr(*e)
+	case MultiError:
+		return goStringMulti(e)
+	case *MultiError:
+		if e == nil {
+			return "(*errors.MultiError)(nil)"
+		}
+		return goStringMulti(*e)
+	default:
+		return fmt.Sprintf("%#v", err)
+	}
+}
+
+// goStringErr returns a Go-syntax representation of e and its chain. Empty fields are omitted, data is redacted and
+// stacks are rendered as their symbolized frames.
+func goStringErr(e Err) string {
+	fields := []string{fmt.Sprintf("Message:%q", e.Message)}
+
+	if e.Code != "" {
+		fields = append(fields, fmt.Sprintf("Code:%q", e.Code))
+	}
+
+	if e.Data != nil {
+		data := e.Data.Redacted()
+		pairs := make([]string, 0, len(data))
+		for _, k := range data.Keys(e.DataOrder...) {
+			pairs = append(pairs, fmt.Sprintf("%q:%#v", k, data[k]))
+		}
+		fields = append(fields, fmt.Sprintf("Data:errors.Data{%s}", strings.Join(pairs, ", ")))
+	}
+
+	if len(e.Stack) > 0 {
+		lines := e.Stack.Strings()
+		for i, line := range lines {
+			lines[i] = fmt.Sprintf("%q", line)
+		}
+		fields = append(fields, fmt.Sprintf("Stack:errors.Stack{%s}", strings.Join(lines, ", ")))
+	}
+
+	if e.Cause != nil {
+		fields = append(fields, "Cause:"+goString(e.Cause))
+	}
+
+	return fmt.Sprintf("errors.Err{%s}", strings.Join(fields, ", "))
+}
+
+// goStringMulti returns a Go-syntax representation of m and the chain of every error it holds.
+func goStringMulti(m MultiError) string {
+	errs := make([]string, len(m.Errors))
+	for i, err := range m.Errors {
+		errs[i] = goString(err)
+	}
+
+	return fmt.Sprintf("errors.MultiError{Errors:[]error{%s}}", strings.Join(errs, ", "))
+}
+
 // format returns a formatted string representation of the error and its cause.
 func format(err error, lvl int) string {
-	t := reflect.TypeOf(err)
-	if t != reflect.TypeOf(Err{}) && t != reflect.TypeOf(&Err{}) {
+	e, ok := toErr(err)
+	if !ok {
+		if multi, ok := err.(multiUnwrapper); ok {
+			return formatMulti(err.Error(), multi.Unwrap(), lvl)
+		}
 		return fmt.Sprintf("\t%s", err.Error())
 	}
 
-	var e Err
-	if t.Kind() == reflect.Ptr {
-		ep := err.(*Err)
-		e = *ep
-	} else {
-		e = err.(Err)
-	}
-
 	var b bytes.Buffer
 	b.WriteString(fmt.Sprintf("message:\n\t\"%s\"", e.Message))
 
+	if e.Code != "" {
+		b.WriteString(fmt.Sprintf("\ncode:\n\t%s", e.Code))
+	}
+
 	if e.Data != nil {
 		b.WriteString("\ndata:")
-		for k, v := range e.Data {
-			b.WriteString(fmt.Sprintf("\n\t%s: %v", k, v))
+		data := e.Data.Redacted()
+		for _, k := range data.Keys(e.DataOrder...) {
+			b.WriteString(fmt.Sprintf("\n\t%s: %v", k, data[k]))
 		}
 	}
 
 	if e.Stack != nil && len(e.Stack) > 0 {
-		firstStackLine := e.Stack[0]
-		b.WriteString(fmt.Sprintf("\nstack:\n%s", indent(firstStackLine, 1)))
-		for i := 1; i < len(e.Stack); i++ {
-			b.WriteString(fmt.Sprintf("\n%s", indent(e.Stack[i], 1)))
+		lines := stackLines(e)
+		b.WriteString(fmt.Sprintf("\nstack:\n%s", indent(lines[0], 1)))
+		for i := 1; i < len(lines); i++ {
+			b.WriteString(fmt.Sprintf("\n%s", indent(lines[i], 1)))
 		}
 	}
 
@@ -47,6 +130,28 @@ func format(err error, lvl int) string {
 	return indent(b.String(), lvl)
 }
 
+// formatMulti returns a formatted string representation of an error wrapping multiple errors, with each one of them
+// numbered and formatted under "causes:".
+func formatMulti(msg string, errs []error, lvl int) string {
+	var b bytes.Buffer
+	b.WriteString(fmt.Sprintf("message:\n%s", indent(fmt.Sprintf("\"%s\"", msg), 1)))
+
+	n := 0
+	for _, child := range errs {
+		if isNil(child) {
+			continue
+		}
+
+		if n == 0 {
+			b.WriteString("\ncauses:")
+		}
+		n++
+		b.WriteString(fmt.Sprintf("\n%s", indent(fmt.Sprintf("%d:\n%s", n, format(child, 1)), 1)))
+	}
+
+	return indent(b.String(), lvl)
+}
+
 // indent indents a string by the given number of times.
 func indent(s string, times int) string {
 	var indent bytes.Buffer
@@ -0,0 +1,222 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Formatter renders an error and its causes. The Formatter returned by CurrentFormatter is used by the %+v format of
+// Err and MultiError.
+type Formatter interface {
+	Format(err error) string
+}
+
+var (
+	// VerboseFormatter renders every error of the chain in an indented "message:/code:/data:/stack:/cause:" layout,
+	// which is the default.
+	VerboseFormatter Formatter = verboseFormatter{}
+	// CompactFormatter renders the chain in a single line, with the data and the first stack frame of each error.
+	CompactFormatter Formatter = compactFormatter{}
+	// LogfmtFormatter renders the chain as logfmt key=value pairs, with the keys of the causes prefixed by "cause.".
+	LogfmtFormatter Formatter = logfmtFormatter{}
+	// MarkdownFormatter renders the chain as Markdown, with the causes nested in block quotes.
+	MarkdownFormatter Formatter = markdownFormatter{}
+)
+
+// FormatWith renders err using f.
+func FormatWith(err error, f Formatter) string {
+	return f.Format(err)
+}
+
+// verboseFormatter is the Formatter behind VerboseFormatter.
+type verboseFormatter struct{}
+
+func (verboseFormatter) Format(err error) string {
+	if isNil(err) {
+		return ""
+	}
+
+	return format(err, 0)
+}
+
+// compactFormatter is the Formatter behind CompactFormatter.
+type compactFormatter struct{}
+
+func (f compactFormatter) Format(err error) string {
+	if isNil(err) {
+		return ""
+	}
+
+	e, ok := toErr(err)
+	if !ok {
+		if multi, ok := err.(multiUnwrapper); ok {
+			causes := make([]string, 0)
+			for _, child := range multi.Unwrap() {
+				if !isNil(child) {
+					causes = append(causes, fmt.Sprintf("%d: %s", len(causes)+1, f.Format(child)))
+				}
+			}
+			return fmt.Sprintf("%s [%s]", err.Error(), strings.Join(causes, "; "))
+		}
+		return err.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(e.Message)
+
+	if e.Code != "" {
+		b.WriteString(fmt.Sprintf(" [%s]", e.Code))
+	}
+
+	if e.Data != nil {
+		data := e.Data.Redacted()
+		pairs := make([]string, 0, len(data))
+		for _, k := range data.Keys(e.DataOrder...) {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", k, data[k]))
+		}
+		b.WriteString(fmt.Sprintf(" {%s}", strings.Join(pairs, " ")))
+	}
+
+	if frames := e.Stack.Frames(); len(frames) > 0 {
+		b.WriteString(fmt.Sprintf(" (%v)", frames[0]))
+	}
+
+	if e.Cause != nil {
+		b.WriteString(": ")
+		b.WriteString(f.Format(e.Cause))
+	}
+
+	return b.String()
+}
+
+// logfmtFormatter is the Formatter behind LogfmtFormatter.
+type logfmtFormatter struct{}
+
+func (f logfmtFormatter) Format(err error) string {
+	var pairs []string
+	f.appendPairs(&pairs, "", err)
+	return strings.Join(pairs, " ")
+}
+
+// appendPairs appends the key=value pairs representing err and its causes to pairs, with every key prefixed by prefix.
+func (f logfmtFormatter) appendPairs(pairs *[]string, prefix string, err error) {
+	if isNil(err) {
+		return
+	}
+
+	e, ok := toErr(err)
+	if !ok {
+		*pairs = append(*pairs, logfmtPair(prefix+"message", err.Error()))
+		if multi, ok := err.(multiUnwrapper); ok {
+			n := 0
+			for _, child := range multi.Unwrap() {
+				if !isNil(child) {
+					n++
+					f.appendPairs(pairs, fmt.Sprintf("%scauses.%d.", prefix, n), child)
+				}
+			}
+		}
+		return
+	}
+
+	*pairs = append(*pairs, logfmtPair(prefix+"message", e.Message))
+
+	if e.Code != "" {
+		*pairs = append(*pairs, logfmtPair(prefix+"code", string(e.Code)))
+	}
+
+	data := e.Data.Redacted()
+	for _, k := range data.Keys(e.DataOrder...) {
+		*pairs = append(*pairs, logfmtPair(prefix+"data."+k, fmt.Sprint(data[k])))
+	}
+
+	if frames := e.Stack.Frames(); len(frames) > 0 {
+		*pairs = append(*pairs, logfmtPair(prefix+"stack", frames[0].String()))
+	}
+
+	f.appendPairs(pairs, prefix+"cause.", e.Cause)
+}
+
+// logfmtPair returns a logfmt key=value pair, replacing the characters not allowed in the key by underscores and
+// quoting the value if needed.
+func logfmtPair(key, value string) string {
+	key = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n\\") {
+		value = strconv.Quote(value)
+	}
+
+	return key + "=" + value
+}
+
+// markdownFormatter is the Formatter behind MarkdownFormatter.
+type markdownFormatter struct{}
+
+func (f markdownFormatter) Format(err error) string {
+	if isNil(err) {
+		return ""
+	}
+
+	var b bytes.Buffer
+
+	e, ok := toErr(err)
+	if !ok {
+		b.WriteString(fmt.Sprintf("**%s**", err.Error()))
+		if multi, ok := err.(multiUnwrapper); ok {
+			n := 0
+			for _, child := range multi.Unwrap() {
+				if !isNil(child) {
+					n++
+					b.WriteString(fmt.Sprintf("\n\nCause %d:\n\n%s", n, quote(f.Format(child))))
+				}
+			}
+		}
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("**%s**", e.Message))
+
+	if e.Code != "" || e.Data != nil {
+		b.WriteString("\n")
+	}
+
+	if e.Code != "" {
+		b.WriteString(fmt.Sprintf("\n- code: `%s`", e.Code))
+	}
+
+	data := e.Data.Redacted()
+	for _, k := range data.Keys(e.DataOrder...) {
+		b.WriteString(fmt.Sprintf("\n- %s: `%v`", k, data[k]))
+	}
+
+	if len(e.Stack) > 0 {
+		b.WriteString(fmt.Sprintf("\n\n```\n%s\n```", strings.Join(stackLines(e), "\n")))
+	}
+
+	if e.Cause != nil {
+		b.WriteString(fmt.Sprintf("\n\nCaused by:\n\n%s", quote(f.Format(e.Cause))))
+	}
+
+	return b.String()
+}
+
+// quote prefixes every line of s with a Markdown block quote marker.
+func quote(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,149 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// newFormatterChain returns the chain of errors used by the formatter tests, created without stack traces so the
+// output is deterministic.
+func newFormatterChain() error {
+	defer SetStackCapture(StackCaptureEnabled())
+	SetStackCapture(false)
+
+	return Wrapd(
+		NewMulti(NewCode("DB_TIMEOUT", "connection timeout"), stderrors.New("network unreachable")),
+		Data{"userId": "67890", "bank": "bank 123"},
+		"failed to complete the transaction",
+	)
+}
+
+func TestFormatters(t *testing.T) {
+	tests := []struct {
+		name      string
+		formatter Formatter
+		expected  string
+	}{
+		{
+			name:      "VerboseFormatter",
+			formatter: VerboseFormatter,
+			expected: strings.Join([]string{
+				"message:",
+				"\t\"failed to complete the transaction\"",
+				"data:",
+				"\tbank: bank 123",
+				"\tuserId: 67890",
+				"cause:",
+				"\tmessage:",
+				"\t\t\"first of 2 errors: connection timeout\"",
+				"\tcauses:",
+				"\t\t1:",
+				"\t\t\tmessage:",
+				"\t\t\t\t\"connection timeout\"",
+				"\t\t\tcode:",
+				"\t\t\t\tDB_TIMEOUT",
+				"\t\t2:",
+				"\t\t\tnetwork unreachable",
+			}, "\n"),
+		},
+		{
+			name:      "CompactFormatter",
+			formatter: CompactFormatter,
+			expected:  "failed to complete the transaction {bank=bank 123 userId=67890}: first of 2 errors: connection timeout [1: connection timeout [DB_TIMEOUT]; 2: network unreachable]",
+		},
+		{
+			name:      "LogfmtFormatter",
+			formatter: LogfmtFormatter,
+			expected:  `message="failed to complete the transaction" data.bank="bank 123" data.userId=67890 cause.message="first of 2 errors: connection timeout" cause.causes.1.message="connection timeout" cause.causes.1.code=DB_TIMEOUT cause.causes.2.message="network unreachable"`,
+		},
+		{
+			name:      "MarkdownFormatter",
+			formatter: MarkdownFormatter,
+			expected: strings.Join([]string{
+				"**failed to complete the transaction**",
+				"",
+				"- bank: `bank 123`",
+				"- userId: `67890`",
+				"",
+				"Caused by:",
+				"",
+				"> **first of 2 errors: connection timeout**",
+				">",
+				"> Cause 1:",
+				">",
+				"> > **connection timeout**",
+				"> >",
+				"> > - code: `DB_TIMEOUT`",
+				">",
+				"> Cause 2:",
+				">",
+				"> > **network unreachable**",
+			}, "\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("when an error is rendered with the %s, it should match the expected layout", tt.name), func(t *testing.T) {
+			if got := FormatWith(newFormatterChain(), tt.formatter); got != tt.expected {
+				t.Errorf("unexpected output, got:\n%s\nexpected:\n%s", got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("when a formatter is set globally, %+v should use it", func(t *testing.T) {
+		defer SetFormatter(CurrentFormatter())
+		SetFormatter(CompactFormatter)
+
+		err := newFormatterChain()
+		if got := fmt.Sprintf("%+v", err); got != FormatWith(err, CompactFormatter) {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, FormatWith(err, CompactFormatter))
+		}
+
+		multiErr := err.(*Err).Cause
+		if got := fmt.Sprintf("%+v", multiErr); got != FormatWith(multiErr, CompactFormatter) {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, FormatWith(multiErr, CompactFormatter))
+		}
+	})
+
+	t.Run("when the stack is captured, the compact and logfmt formatters should render the first frame", func(t *testing.T) {
+		err := New("failed")
+		frame := err.(*Err).Stack.Frames()[0]
+
+		if got := FormatWith(err, CompactFormatter); got != fmt.Sprintf("failed (%v)", frame) {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, fmt.Sprintf("failed (%v)", frame))
+		}
+
+		if got := FormatWith(err, LogfmtFormatter); !strings.Contains(got, fmt.Sprintf("stack=%q", frame.String())) {
+			t.Errorf(`expected the first frame to be in the output, got "%s"`, got)
+		}
+	})
+
+	t.Run("when a logfmt key holds invalid characters, they should be replaced", func(t *testing.T) {
+		defer SetStackCapture(StackCaptureEnabled())
+		SetStackCapture(false)
+
+		err := Errord(Data{"user id": 1}, "failed")
+		if got := FormatWith(err, LogfmtFormatter); got != "message=failed data.user_id=1" {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, "message=failed data.user_id=1")
+		}
+	})
+
+	t.Run("when the error is nil, every built-in formatter should return an empty string", func(t *testing.T) {
+		formatters := map[string]Formatter{
+			"VerboseFormatter":  VerboseFormatter,
+			"CompactFormatter":  CompactFormatter,
+			"LogfmtFormatter":   LogfmtFormatter,
+			"MarkdownFormatter": MarkdownFormatter,
+		}
+
+		for name, f := range formatters {
+			for _, err := range []error{nil, (*Err)(nil)} {
+				if got := FormatWith(err, f); got != "" {
+					t.Errorf(`unexpected output for the %s, got "%s", expected an empty string`, name, got)
+				}
+			}
+		}
+	})
+}
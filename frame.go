@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Frame represents a single symbolized frame of a stack trace.
+type Frame struct {
+	// PC is the program counter of the frame, or 0 for frames restored by ParseStack.
+	PC uintptr
+	// Function is the fully qualified name of the function, e.g. "github.com/zignd/errors.New".
+	Function string
+	// Package is the import path of the package the function belongs to, e.g. "github.com/zignd/errors".
+	Package string
+	// File is the full path of the source file.
+	File string
+	// Line is the line number in the source file.
+	Line int
+
+	// raw is the original string representation of a frame restored by ParseStack.
+	raw string
+}
+
+// newFrame converts a frame reported by runtime.CallersFrames into a Frame.
+func newFrame(frame runtime.Frame) Frame {
+	return Frame{
+		PC:       frame.PC,
+		Function: frame.Function,
+		Package:  packageName(frame.Function),
+		File:     frame.File,
+		Line:     frame.Line,
+	}
+}
+
+// parseFrame restores a Frame from its "function @ file:line" string representation. Strings in any other layout are
+// preserved as they are by String.
+func parseFrame(s string) Frame {
+	frame := Frame{raw: s}
+
+	function, location, ok := strings.Cut(s, " @ ")
+	if !ok {
+		return frame
+	}
+
+	i := strings.LastIndex(location, ":")
+	if i < 0 {
+		return frame
+	}
+
+	line, err := strconv.Atoi(location[i+1:])
+	if err != nil {
+		return frame
+	}
+
+	frame.Function = function
+	frame.Package = packageName(function)
+	frame.File = location[:i]
+	frame.Line = line
+
+	return frame
+}
+
+// String returns the frame in the "function @ file:line" layout used by format() and MarshalJSON.
+func (f Frame) String() string {
+	if f.raw != "" {
+		return f.raw
+	}
+
+	if f.Function == "" {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s @ %s:%d", f.Function, f.File, f.Line)
+}
+
+// Format implements fmt.Formatter. It accepts the following verbs:
+//
+//	%s    source file name
+//	%d    source line
+//	%n    function name without the package path
+//	%v    equivalent to %s:%d
+//
+// And the following flags:
+//
+//	%+s   function name and full path of the source file separated by \n\t
+//	%+v   equivalent to %+s:%d
+func (f Frame) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 's':
+		switch {
+		case s.Flag('+'):
+			if f.Function == "" {
+				io.WriteString(s, "unknown")
+			} else {
+				io.WriteString(s, f.Function)
+			}
+			io.WriteString(s, "\n\t")
+			io.WriteString(s, f.file())
+		default:
+			io.WriteString(s, path.Base(f.file()))
+		}
+	case 'd':
+		io.WriteString(s, strconv.Itoa(f.Line))
+	case 'n':
+		io.WriteString(s, shortFuncName(f.Function))
+	case 'v':
+		f.Format(s, 's')
+		io.WriteString(s, ":")
+		f.Format(s, 'd')
+	}
+}
+
+// file returns the source file of the frame or "unknown" if it could not be resolved.
+func (f Frame) file() string {
+	if f.File == "" {
+		return "unknown"
+	}
+
+	return f.File
+}
+
+// packageName extracts the package import path from a fully qualified function name.
+func packageName(name string) string {
+	dir := ""
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		dir, name = name[:i+1], name[i+1:]
+	}
+
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+
+	return dir + name
+}
+
+// shortFuncName removes the package path from a fully qualified function name.
+func shortFuncName(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name
+}
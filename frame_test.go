@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// inlinedNewLine is the line where inlinedNew calls New.
+const inlinedNewLine = 14
+
+// inlinedNew is small enough to be inlined by the compiler into its callers.
+func inlinedNew() error {
+	return New("error message")
+}
+
+func TestFrames(t *testing.T) {
+	t.Run("when Frames is called on a stack, it should return structured frames for each program counter", func(t *testing.T) {
+		err := New("error message").(*Err)
+
+		frames := err.Stack.Frames()
+		if len(frames) < len(err.Stack) {
+			t.Fatalf("unexpected number of frames, got %d, expected at least %d", len(frames), len(err.Stack))
+		}
+
+		frame := frames[0]
+		if !strings.HasSuffix(frame.Function, ".TestFrames.func1") {
+			t.Errorf(`unexpected function, got "%s", expected a suffix of "%s"`, frame.Function, ".TestFrames.func1")
+		}
+		if frame.Package != "github.com/zignd/errors" {
+			t.Errorf(`unexpected package, got "%s", expected "%s"`, frame.Package, "github.com/zignd/errors")
+		}
+		if !strings.HasSuffix(frame.File, "frame_test.go") {
+			t.Errorf(`unexpected file, got "%s", expected a suffix of "%s"`, frame.File, "frame_test.go")
+		}
+		if frame.Line == 0 {
+			t.Errorf("unexpected line, got 0, expected a positive number")
+		}
+		if frame.PC == 0 {
+			t.Errorf("unexpected program counter, got 0, expected a non-zero value")
+		}
+	})
+
+	t.Run("when an error is created inside an inlined function, it should report the inlined function as its own frame", func(t *testing.T) {
+		err := inlinedNew().(*Err)
+
+		frames := err.Stack.Frames()
+		if len(frames) < 2 {
+			t.Fatalf("unexpected number of frames, got %d, expected at least %d", len(frames), 2)
+		}
+
+		if !strings.HasSuffix(frames[0].Function, ".inlinedNew") {
+			t.Errorf(`unexpected function, got "%s", expected a suffix of "%s"`, frames[0].Function, ".inlinedNew")
+		}
+		if frames[0].Line != inlinedNewLine {
+			t.Errorf("unexpected line, got %d, expected %d", frames[0].Line, inlinedNewLine)
+		}
+		if !strings.HasSuffix(frames[1].Function, ".TestFrames.func2") {
+			t.Errorf(`unexpected function, got "%s", expected a suffix of "%s"`, frames[1].Function, ".TestFrames.func2")
+		}
+	})
+
+	t.Run("when Strings is called on a stack, it should keep the legacy string layout", func(t *testing.T) {
+		err := New("error message").(*Err)
+
+		frame := err.Stack.Frames()[0]
+		expected := fmt.Sprintf("%s @ %s:%d", frame.Function, frame.File, frame.Line)
+		if got := err.Stack.Strings()[0]; got != expected {
+			t.Errorf(`unexpected stack line, got "%s", expected "%s"`, got, expected)
+		}
+	})
+}
+
+func TestFrameFormat(t *testing.T) {
+	frame := Frame{
+		Function: "github.com/zignd/errors/examples.(*Server).Start",
+		Package:  "github.com/zignd/errors/examples",
+		File:     "/home/user/errors/examples/server.go",
+		Line:     42,
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%s", "server.go"},
+		{"%+s", "github.com/zignd/errors/examples.(*Server).Start\n\t/home/user/errors/examples/server.go"},
+		{"%d", "42"},
+		{"%n", "(*Server).Start"},
+		{"%v", "server.go:42"},
+		{"%+v", "github.com/zignd/errors/examples.(*Server).Start\n\t/home/user/errors/examples/server.go:42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("when a frame is formatted with %q, it should return %q", tt.format, tt.expected), func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, frame); got != tt.expected {
+				t.Errorf(`unexpected output, got "%s", expected "%s"`, got, tt.expected)
+			}
+		})
+	}
+
+	t.Run("when an unresolved frame is formatted, it should render unknown values", func(t *testing.T) {
+		if got := fmt.Sprintf("%+v", Frame{}); got != "unknown\n\tunknown:0" {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, "unknown\n\tunknown:0")
+		}
+		if got := (Frame{}).String(); got != "unknown" {
+			t.Errorf(`unexpected output, got "%s", expected "%s"`, got, "unknown")
+		}
+	})
+}
@@ -0,0 +1,19 @@
+module github.com/zignd/errors/grpcerrors
+
+go 1.21.0
+
+require (
+	github.com/zignd/errors v0.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117
+	google.golang.org/grpc v1.66.0
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+)
+
+// The root module has no published release yet, so grpcerrors is built against the local copy.
+replace github.com/zignd/errors => ../
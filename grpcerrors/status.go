@@ -0,0 +1,155 @@
+// Package grpcerrors converts chains of errors created with github.com/zignd/errors to and from gRPC statuses,
+// preserving the message, code, data and stack of every errors.Err in the chain.
+package grpcerrors
+
+import (
+	"fmt"
+
+	"github.com/zignd/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Domain is the domain of the errdetails.ErrorInfo details added by ToStatus.
+const Domain = "github.com/zignd/errors"
+
+// ToStatus converts err into a status with the provided code and the message of err. Every errors.Err in the chain of
+// err, as well as any other errors.StackTracer or errors.DataProvider, is added to the details of the status as an
+// errdetails.ErrorInfo, holding its code as reason and its redacted data as metadata, followed, if debug is enabled, by
+// an errdetails.DebugInfo, holding its message and stack. Debug should only be enabled for trusted clients, as the
+// stacks expose the internals of the server. Data values are converted to strings with fmt.Sprint, as the metadata
+// only holds strings. It returns nil if err is nil.
+func ToStatus(err error, code codes.Code, debug bool) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	st := status.New(code, err.Error())
+
+	details := make([]protoadapt.MessageV1, 0)
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if v, ok := e.(*errors.Err); ok && v == nil {
+			break
+		}
+
+		tracer, isStackTracer := e.(errors.StackTracer)
+		provider, isDataProvider := e.(errors.DataProvider)
+		if !isStackTracer && !isDataProvider {
+			continue
+		}
+
+		var code errors.Code
+		if c, ok := e.(errors.Coder); ok {
+			code = c.ErrorCode()
+		}
+
+		var metadata map[string]string
+		if isDataProvider {
+			data := provider.ErrorData().Redacted()
+			metadata = make(map[string]string, len(data))
+			for k, v := range data {
+				metadata[k] = fmt.Sprint(v)
+			}
+		}
+
+		details = append(details, &errdetails.ErrorInfo{
+			Reason:   string(code),
+			Domain:   Domain,
+			Metadata: metadata,
+		})
+
+		if debug {
+			var stack errors.Stack
+			if isStackTracer {
+				stack = tracer.StackTrace()
+			}
+
+			details = append(details, &errdetails.DebugInfo{
+				Detail:       errors.MessageOf(e),
+				StackEntries: stack.Strings(),
+			})
+		}
+	}
+
+	if len(details) == 0 {
+		return st
+	}
+
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+// Error converts err into a status error with the provided code, as returned by ToStatus(err, code, debug).Err().
+func Error(err error, code codes.Code, debug bool) error {
+	return ToStatus(err, code, debug).Err()
+}
+
+// FromStatus converts a status created by ToStatus back into a chain of *errors.Err values. A status without details
+// added by ToStatus is converted into a single *errors.Err holding its message. When the status was created without
+// debug, the messages of the errors.Err values are unknown, so the innermost one holds the message of the status. It
+// returns nil if st is nil or OK.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var chain []*errors.Err
+	debug := false
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() != Domain {
+				continue
+			}
+
+			e := &errors.Err{Code: errors.Code(d.GetReason())}
+			if len(d.GetMetadata()) > 0 {
+				e.Data = make(errors.Data, len(d.GetMetadata()))
+				for k, v := range d.GetMetadata() {
+					e.Data[k] = v
+				}
+			}
+			chain = append(chain, e)
+		case *errdetails.DebugInfo:
+			if len(chain) == 0 {
+				continue
+			}
+
+			debug = true
+			e := chain[len(chain)-1]
+			e.Message = d.GetDetail()
+			e.Stack = errors.ParseStack(d.GetStackEntries())
+		}
+	}
+
+	if len(chain) == 0 {
+		return &errors.Err{Message: st.Message()}
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		chain[i].Cause = chain[i+1]
+	}
+
+	if !debug {
+		chain[len(chain)-1].Message = st.Message()
+	}
+
+	return chain[0]
+}
+
+// FromError converts an error returned by a gRPC call back into a chain of *errors.Err values, as FromStatus does.
+// Errors that are not status errors are returned unchanged.
+func FromError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	return FromStatus(st)
+}
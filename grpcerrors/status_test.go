@@ -0,0 +1,225 @@
+package grpcerrors
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/zignd/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// domainError is a custom error type exposing its data, code and cause through the interfaces of the errors package.
+type domainError struct {
+	cause error
+}
+
+func (e domainError) Error() string          { return "failed to charge the card: " + e.cause.Error() }
+func (e domainError) ErrorData() errors.Data { return errors.Data{"cardId": "1234"} }
+func (e domainError) ErrorCode() errors.Code { return "CHARGE_FAILED" }
+func (e domainError) Unwrap() error          { return e.cause }
+
+// failingHealthServer is a health server whose Check method fails with err.
+type failingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	err   error
+	code  codes.Code
+	debug bool
+}
+
+func (s *failingHealthServer) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return nil, Error(s.err, s.code, s.debug)
+}
+
+// call serves a failingHealthServer failing with err over an in-process connection and returns the error received
+// by the client.
+func call(t *testing.T, err error, code codes.Code, debug bool) error {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, &failingHealthServer{err: err, code: code, debug: debug})
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, dialErr := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if dialErr != nil {
+		t.Fatalf("unexpected error: %v", dialErr)
+	}
+	defer conn.Close()
+
+	_, callErr := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	return callErr
+}
+
+func TestStatusConversion(t *testing.T) {
+	t.Run("when an Err chain is returned by a server with debug enabled, the client should restore it", func(t *testing.T) {
+		err1 := errors.NewCode("DB_TIMEOUT", "context timeout")
+		err2 := fmt.Errorf("query failed: %w", err1)
+		err3 := errors.Wrapd(err2, errors.Data{"userId": "42", "attempts": 3}, "failed to load the user")
+
+		callErr := call(t, err3, codes.Unavailable, true)
+
+		if got := status.Code(callErr); got != codes.Unavailable {
+			t.Errorf(`unexpected status code, got "%s", expected "%s"`, got, codes.Unavailable)
+		}
+
+		if got := status.Convert(callErr).Message(); got != err3.Error() {
+			t.Errorf(`unexpected status message, got "%s", expected "%s"`, got, err3.Error())
+		}
+
+		restored, ok := FromError(callErr).(*errors.Err)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *errors.Err", FromError(callErr))
+		}
+
+		if restored.Message != "failed to load the user" {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, restored.Message, "failed to load the user")
+		}
+
+		if expected := (errors.Data{"userId": "42", "attempts": "3"}); !reflect.DeepEqual(restored.Data, expected) {
+			t.Errorf("unexpected data, got %v, expected %v", restored.Data, expected)
+		}
+
+		if !reflect.DeepEqual(restored.Stack.Strings(), err3.(*errors.Err).Stack.Strings()) {
+			t.Errorf("unexpected stack, got %v, expected %v", restored.Stack.Strings(), err3.(*errors.Err).Stack.Strings())
+		}
+
+		cause, ok := restored.Cause.(*errors.Err)
+		if !ok {
+			t.Fatalf("unexpected cause type, got %T, expected *errors.Err", restored.Cause)
+		}
+
+		if cause.Message != "context timeout" || cause.Cause != nil {
+			t.Errorf("unexpected cause, got %v", cause)
+		}
+
+		if got := errors.CodeOf(restored); got != "DB_TIMEOUT" {
+			t.Errorf(`unexpected code, got "%s", expected "%s"`, got, "DB_TIMEOUT")
+		}
+	})
+
+	t.Run("when an Err chain is returned by a server with debug disabled, the stacks and messages should not be sent", func(t *testing.T) {
+		err := errors.Wrapd(errors.NewCode("DB_TIMEOUT", "context timeout"), errors.Data{"userId": "42"}, "failed to load the user")
+
+		callErr := call(t, err, codes.Unavailable, false)
+
+		for _, detail := range status.Convert(callErr).Details() {
+			if _, ok := detail.(*errdetails.DebugInfo); ok {
+				t.Errorf("unexpected debug info, got %v", detail)
+			}
+		}
+
+		restored := FromError(callErr)
+		if restored.Error() != err.Error() {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, restored.Error(), err.Error())
+		}
+
+		if got := errors.CodeOf(restored); got != "DB_TIMEOUT" {
+			t.Errorf(`unexpected code, got "%s", expected "%s"`, got, "DB_TIMEOUT")
+		}
+
+		if got, _ := errors.LookupString(restored, "userId"); got != "42" {
+			t.Errorf(`unexpected data, got "%s", expected "%s"`, got, "42")
+		}
+
+		if stack := errors.MergedStack(restored); stack != nil {
+			t.Errorf("expected no stack, got %v", stack.Strings())
+		}
+	})
+
+	t.Run("when a chain mixing Err and *Err values is converted, every one of them should be restored", func(t *testing.T) {
+		inner := errors.Err{Message: "context timeout", Code: "DB_TIMEOUT", Data: errors.Data{"server": "db-server-01"}}
+		err := errors.Wrap(inner, "failed to load the user")
+
+		restored, ok := FromStatus(ToStatus(err, codes.Unavailable, true)).(*errors.Err)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *errors.Err", FromStatus(ToStatus(err, codes.Unavailable, true)))
+		}
+
+		cause, ok := restored.Cause.(*errors.Err)
+		if !ok {
+			t.Fatalf("unexpected cause type, got %T, expected *errors.Err", restored.Cause)
+		}
+
+		if cause.Message != "context timeout" || cause.Code != "DB_TIMEOUT" {
+			t.Errorf("unexpected cause, got %v", cause)
+		}
+
+		if expected := (errors.Data{"server": "db-server-01"}); !reflect.DeepEqual(cause.Data, expected) {
+			t.Errorf("unexpected data, got %v, expected %v", cause.Data, expected)
+		}
+	})
+
+	t.Run("when a chain holds a custom error implementing the interfaces, it should be restored like an Err", func(t *testing.T) {
+		err := errors.Wrap(domainError{cause: errors.New("insufficient funds")}, "failed to complete the order")
+
+		restored := FromStatus(ToStatus(err, codes.FailedPrecondition, true))
+
+		var cause *errors.Err
+		if !errors.As(errors.Unwrap(restored), &cause) {
+			t.Fatalf("unexpected cause, got %v", errors.Unwrap(restored))
+		}
+
+		if cause.Message != "failed to charge the card" || cause.Code != "CHARGE_FAILED" {
+			t.Errorf("unexpected cause, got %v", cause)
+		}
+
+		if expected := (errors.Data{"cardId": "1234"}); !reflect.DeepEqual(cause.Data, expected) {
+			t.Errorf("unexpected data, got %v, expected %v", cause.Data, expected)
+		}
+
+		if restored.Error() != err.Error() {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, restored.Error(), err.Error())
+		}
+	})
+
+	t.Run("when a standard error is returned by a server, the client should restore its message", func(t *testing.T) {
+		callErr := call(t, fmt.Errorf("not found"), codes.NotFound, false)
+
+		restored, ok := FromError(callErr).(*errors.Err)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *errors.Err", FromError(callErr))
+		}
+
+		if restored.Message != "not found" {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, restored.Message, "not found")
+		}
+	})
+
+	t.Run("when ToStatus and FromStatus are provided with nil values, they should return nil", func(t *testing.T) {
+		if st := ToStatus(nil, codes.Internal, false); st != nil {
+			t.Errorf("expected nil, got %v", st)
+		}
+
+		if err := FromStatus(status.New(codes.OK, "")); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("when a chain ends with a nil *Err, ToStatus should stop at it", func(t *testing.T) {
+		st := ToStatus(errors.Wrap((*errors.Err)(nil), "outer"), codes.Internal, true)
+
+		if len(st.Details()) != 2 {
+			t.Errorf("unexpected number of details, got %d, expected %d", len(st.Details()), 2)
+		}
+	})
+
+	t.Run("when FromError is provided with an error that is not a status error, it should return it unchanged", func(t *testing.T) {
+		err := fmt.Errorf("not a status")
+		if got := FromError(err); got != err {
+			t.Errorf("unexpected error, got %v, expected %v", got, err)
+		}
+	})
+}
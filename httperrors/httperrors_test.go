@@ -0,0 +1,281 @@
+package httperrors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zignd/errors"
+)
+
+const codeNotFound errors.Code = "NOT_FOUND"
+
+var errConflict = stderrors.New("conflict")
+
+// serve runs h and returns the response and its decoded problem.
+func serve(t *testing.T, h http.Handler) (*http.Response, map[string]any) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	res := rec.Result()
+
+	var problem map[string]any
+	if err := json.NewDecoder(res.Body).Decode(&problem); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return res, problem
+}
+
+func TestMapperStatus(t *testing.T) {
+	m := NewMapper().
+		MapCode(codeNotFound, http.StatusNotFound).
+		MapError(errConflict, http.StatusConflict).
+		MapError(io.ErrUnexpectedEOF, http.StatusBadRequest)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"when the chain holds a mapped code, it should return the mapped status", errors.Wrap(errors.NewCode(codeNotFound, "user not found"), "failed"), http.StatusNotFound},
+		{"when the chain holds a mapped sentinel, it should return the mapped status", errors.Wrap(errConflict, "failed"), http.StatusConflict},
+		{"when the chain holds a mapped standard sentinel, it should return the mapped status", errors.Wrap(io.ErrUnexpectedEOF, "failed"), http.StatusBadRequest},
+		{"when the chain holds nothing mapped, it should return 500", errors.New("failed"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Status(tt.err); got != tt.expected {
+				t.Errorf("unexpected status, got %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Run("when the handler returns an error, it should write a problem+json response", func(t *testing.T) {
+		m := NewMapper().MapCode(codeNotFound, http.StatusNotFound)
+		h := m.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			err := errors.NewCode(codeNotFound, "record not found")
+			return errors.Wrapd(err, errors.Data{"userId": "42"}, "user not found")
+		})
+
+		res, problem := serve(t, h)
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status, got %d, expected %d", res.StatusCode, http.StatusNotFound)
+		}
+
+		if got := res.Header.Get("Content-Type"); got != ContentType {
+			t.Errorf(`unexpected content type, got "%s", expected "%s"`, got, ContentType)
+		}
+
+		if problem["title"] != "user not found" {
+			t.Errorf(`unexpected title, got "%v", expected "%s"`, problem["title"], "user not found")
+		}
+
+		if problem["status"] != float64(http.StatusNotFound) {
+			t.Errorf("unexpected status member, got %v, expected %d", problem["status"], http.StatusNotFound)
+		}
+
+		if problem["userId"] != "42" {
+			t.Errorf(`unexpected userId extension, got "%v", expected "%s"`, problem["userId"], "42")
+		}
+
+		if problem["code"] != string(codeNotFound) {
+			t.Errorf(`unexpected code extension, got "%v", expected "%s"`, problem["code"], codeNotFound)
+		}
+
+		if _, ok := problem["stack"]; ok {
+			t.Errorf("unexpected stack extension, got %v, expected undefined key", problem["stack"])
+		}
+
+		if _, ok := problem["detail"]; ok {
+			t.Errorf("unexpected detail, got %v, expected undefined key", problem["detail"])
+		}
+	})
+
+	t.Run("when debug is enabled, it should include the detail and stack", func(t *testing.T) {
+		m := NewMapper()
+		m.Debug = true
+		h := m.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return errors.Wrap(errors.New("connection refused"), "failed to load the user")
+		})
+
+		_, problem := serve(t, h)
+
+		if problem["detail"] != "failed to load the user: connection refused" {
+			t.Errorf(`unexpected detail, got "%v", expected "%s"`, problem["detail"], "failed to load the user: connection refused")
+		}
+
+		if stack, ok := problem["stack"].([]any); !ok || len(stack) == 0 {
+			t.Errorf("unexpected stack extension, got %v, expected a non-empty array", problem["stack"])
+		}
+	})
+
+	t.Run("when the handler panics, it should write a 500 problem+json response", func(t *testing.T) {
+		h := NewMapper().Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		})
+
+		res, problem := serve(t, h)
+
+		if res.StatusCode != http.StatusInternalServerError {
+			t.Errorf("unexpected status, got %d, expected %d", res.StatusCode, http.StatusInternalServerError)
+		}
+
+		if expected := http.StatusText(http.StatusInternalServerError); problem["title"] != expected {
+			t.Errorf(`unexpected title, got "%v", expected "%s"`, problem["title"], expected)
+		}
+	})
+
+	t.Run("when the handler panics and debug is enabled, it should use the panic message as title", func(t *testing.T) {
+		m := NewMapper()
+		m.Debug = true
+		h := m.Handler(func(w http.ResponseWriter, r *http.Request) error {
+			panic("boom")
+		})
+
+		_, problem := serve(t, h)
+
+		if problem["title"] != "panic: boom" {
+			t.Errorf(`unexpected title, got "%v", expected "%s"`, problem["title"], "panic: boom")
+		}
+	})
+
+	t.Run("when the outermost errors.Err has no message, it should use the next message or the status text as title", func(t *testing.T) {
+		errNotFound := errors.NewSentinel("user not found")
+
+		tests := []struct {
+			err      error
+			expected string
+		}{
+			{errors.Raise(errNotFound), http.StatusText(http.StatusNotFound)},
+			{errors.WithStack(errConflict), http.StatusText(http.StatusNotFound)},
+			{errors.WithData(errConflict, errors.Data{"userId": "42"}), http.StatusText(http.StatusNotFound)},
+			{errors.WithStack(errors.New("user not found")), "user not found"},
+			{errors.WithStack((*errors.Err)(nil)), http.StatusText(http.StatusNotFound)},
+		}
+
+		for _, tt := range tests {
+			if got := NewProblem(tt.err, http.StatusNotFound, false).Title; got != tt.expected {
+				t.Errorf(`unexpected title for "%v", got "%s", expected "%s"`, tt.err, got, tt.expected)
+			}
+		}
+	})
+
+	t.Run("when the data is held by a cause, the extensions should still hold it", func(t *testing.T) {
+		tests := []error{
+			errors.Wrap(errors.Errord(errors.Data{"userId": "42"}, "user not found"), "failed"),
+			fmt.Errorf("ctx: %w", errors.WithData(fmt.Errorf("foreign: %w", errors.WithStack(
+				errors.Wrapd(errors.New("inner"), errors.Data{"userId": "42"}, "user not found"))), nil)),
+		}
+
+		for _, err := range tests {
+			if p := NewProblem(err, http.StatusNotFound, false); p.Extensions["userId"] != "42" {
+				t.Errorf(`unexpected extensions for "%v", got %v`, err, p.Extensions)
+			}
+		}
+	})
+
+	t.Run("when the handler returns a standard error, it should use the status text as title", func(t *testing.T) {
+		h := NewMapper().MapError(errConflict, http.StatusConflict).Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return errConflict
+		})
+
+		res, problem := serve(t, h)
+
+		if res.StatusCode != http.StatusConflict {
+			t.Errorf("unexpected status, got %d, expected %d", res.StatusCode, http.StatusConflict)
+		}
+
+		if problem["title"] != http.StatusText(http.StatusConflict) {
+			t.Errorf(`unexpected title, got "%v", expected "%s"`, problem["title"], http.StatusText(http.StatusConflict))
+		}
+	})
+
+	t.Run("when the handler returns an errors.Err value, it should be handled like a *errors.Err", func(t *testing.T) {
+		h := NewMapper().MapCode(codeNotFound, http.StatusNotFound).Handler(func(w http.ResponseWriter, r *http.Request) error {
+			return fmt.Errorf("failed to load the user: %w", errors.Err{Message: "user not found", Code: codeNotFound, Data: errors.Data{"userId": "42"}})
+		})
+
+		res, problem := serve(t, h)
+
+		if res.StatusCode != http.StatusNotFound {
+			t.Errorf("unexpected status, got %d, expected %d", res.StatusCode, http.StatusNotFound)
+		}
+
+		if problem["title"] != "user not found" || problem["userId"] != "42" || problem["code"] != string(codeNotFound) {
+			t.Errorf("unexpected extensions, got %v", problem)
+		}
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("when the wrapped handler panics, it should write a 500 problem+json response", func(t *testing.T) {
+		h := NewMapper().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(errors.New("boom"))
+		}))
+
+		res, problem := serve(t, h)
+
+		if res.StatusCode != http.StatusInternalServerError {
+			t.Errorf("unexpected status, got %d, expected %d", res.StatusCode, http.StatusInternalServerError)
+		}
+
+		if expected := http.StatusText(http.StatusInternalServerError); problem["title"] != expected {
+			t.Errorf(`unexpected title, got "%v", expected "%s"`, problem["title"], expected)
+		}
+	})
+
+	t.Run("when the wrapped handler panics with http.ErrAbortHandler, it should propagate the panic", func(t *testing.T) {
+		h := NewMapper().Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		defer func() {
+			if v := recover(); v != http.ErrAbortHandler {
+				t.Errorf("unexpected panic value, got %v, expected %v", v, http.ErrAbortHandler)
+			}
+		}()
+
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func TestProblemMarshalJSON(t *testing.T) {
+	t.Run("when a problem has extensions, they should not override the standard members", func(t *testing.T) {
+		p := Problem{
+			Title:      "user not found",
+			Status:     http.StatusNotFound,
+			Extensions: map[string]any{"status": "overridden", "userId": "42"},
+		}
+
+		b, err := json.Marshal(p)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `{"title":"user not found","status":404,"userId":"42"}`
+		if string(b) != expected {
+			t.Errorf(`unexpected JSON, got "%s", expected "%s"`, b, expected)
+		}
+	})
+}
+
+func TestProblemRedaction(t *testing.T) {
+	t.Run("when the data holds a secret, the problem should redact it", func(t *testing.T) {
+		err := errors.Errord(errors.Data{"token": errors.NewSecret("s3cr3t")}, "invalid token")
+
+		p := NewProblem(err, http.StatusUnauthorized, true)
+		if p.Extensions["token"] != errors.RedactedValue {
+			t.Errorf(`unexpected token extension, got "%v", expected "%s"`, p.Extensions["token"], errors.RedactedValue)
+		}
+	})
+}
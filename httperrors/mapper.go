@@ -0,0 +1,70 @@
+// Package httperrors converts chains of errors created with github.com/zignd/errors into HTTP responses, using the
+// application/problem+json format described by RFC 7807.
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/zignd/errors"
+)
+
+// Mapper maps errors to HTTP status codes and writes them as problem+json responses. Errors are mapped by the code of
+// their chain first, then by the sentinel errors they match, in the order they were registered. Errors not mapped
+// by either are mapped to http.StatusInternalServerError.
+type Mapper struct {
+	// Debug enables the detail and stack members of the problems, which expose the internals of the errors.
+	Debug bool
+
+	codes     map[errors.Code]int
+	sentinels []sentinelStatus
+}
+
+// sentinelStatus is the HTTP status code mapped to a sentinel error.
+type sentinelStatus struct {
+	target error
+	status int
+}
+
+// NewMapper returns a new Mapper without any mapping.
+func NewMapper() *Mapper {
+	return &Mapper{
+		codes: make(map[errors.Code]int),
+	}
+}
+
+// MapCode maps the errors whose chain holds code to status.
+func (m *Mapper) MapCode(code errors.Code, status int) *Mapper {
+	m.codes[code] = status
+	return m
+}
+
+// MapError maps the errors matching target according to errors.Is to status.
+func (m *Mapper) MapError(target error, status int) *Mapper {
+	m.sentinels = append(m.sentinels, sentinelStatus{target: target, status: status})
+	return m
+}
+
+// Status returns the HTTP status code mapped to err.
+func (m *Mapper) Status(err error) int {
+	if status, ok := m.codes[errors.CodeOf(err)]; ok {
+		return status
+	}
+
+	for _, s := range m.sentinels {
+		if errors.Is(err, s.target) {
+			return s.status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Problem returns the problem representing err, with the status code mapped to it.
+func (m *Mapper) Problem(err error) *Problem {
+	return NewProblem(err, m.Status(err), m.Debug)
+}
+
+// WriteError writes err to w as a problem+json response with the status code mapped to it.
+func (m *Mapper) WriteError(w http.ResponseWriter, err error) {
+	m.Problem(err).Write(w)
+}
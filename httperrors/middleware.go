@@ -0,0 +1,46 @@
+package httperrors
+
+import (
+	"net/http"
+
+	"github.com/zignd/errors"
+)
+
+// HandlerFunc is an HTTP handler that returns an error instead of writing it to the response.
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// Handler returns an http.Handler that runs h, writing the error it returns as a problem+json response. Panics are
+// recovered and written as well.
+func (m *Mapper) Handler(h HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer m.recover(w)
+
+		if err := h(w, r); err != nil {
+			m.WriteError(w, err)
+		}
+	})
+}
+
+// Middleware returns an http.Handler that runs next, recovering its panics and writing them as problem+json responses.
+func (m *Mapper) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer m.recover(w)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// recover recovers a panic and writes it to w as a problem+json response. The http.ErrAbortHandler panics are
+// propagated, as they are meant to abort the response.
+func (m *Mapper) recover(w http.ResponseWriter) {
+	v := recover()
+	if v == nil {
+		return
+	}
+
+	if v == http.ErrAbortHandler {
+		panic(v)
+	}
+
+	m.WriteError(w, errors.NewPanicError(v))
+}
@@ -0,0 +1,142 @@
+package httperrors
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/zignd/errors"
+)
+
+// ContentType is the media type of the problem responses.
+const ContentType = "application/problem+json"
+
+// Problem is a problem details object as described by RFC 7807.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Extensions holds the extension members of the problem, which are marshalled alongside the standard ones.
+	Extensions map[string]any `json:"-"`
+}
+
+// NewProblem returns the problem representing err with the provided status code. The title is the message of the
+// outermost errors.Err in the chain of err holding one, the extensions hold the code and the redacted data of the
+// whole chain, as returned by errors.CodeOf and errors.DataOf, and, if debug is enabled, the detail holds the full
+// message of the chain and the "stack" extension holds the stack returned by errors.MergedStack. The title falls back to the status text when no
+// errors.Err holds a message, and when err is an *errors.PanicError unless debug is enabled, as the panic value may
+// expose the internals of the server.
+func NewProblem(err error, status int, debug bool) *Problem {
+	p := &Problem{
+		Title:      http.StatusText(status),
+		Status:     status,
+		Extensions: make(map[string]any),
+	}
+
+	var panicErr *errors.PanicError
+	if title := titleOf(err); title != "" && (debug || !errors.As(err, &panicErr)) {
+		p.Title = title
+	}
+
+	for k, v := range errors.DataOf(err).Redacted() {
+		p.Extensions[k] = v
+	}
+
+	if code := errors.CodeOf(err); code != "" {
+		p.Extensions["code"] = code
+	}
+
+	if stack := errors.MergedStack(err); debug && len(stack) > 0 {
+		p.Extensions["stack"] = stack.Strings()
+	}
+
+	if debug && err != nil {
+		p.Detail = err.Error()
+	}
+
+	return p
+}
+
+// titleOf returns the message of the outermost errors.Err in the chain of err holding one, skipping the ones without a
+// message of their own, such as the ones returned by errors.WithStack for errors of other types.
+func titleOf(err error) string {
+	for err != nil {
+		var e *errors.Err
+		if !errors.As(err, &e) || e == nil {
+			return ""
+		}
+
+		if e.Message != "" {
+			return e.Message
+		}
+		err = e.Cause
+	}
+
+	return ""
+}
+
+// MarshalJSON implements json.Marshaler. The extension members are marshalled after the standard ones, which take
+// precedence over extensions sharing their names.
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	b, err := json.Marshal(problem(p))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p.Extensions) == 0 {
+		return b, nil
+	}
+
+	var standard map[string]json.RawMessage
+	if err := json.Unmarshal(b, &standard); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(p.Extensions))
+	for k := range p.Extensions {
+		if _, ok := standard[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(b[:len(b)-1])
+	for _, k := range keys {
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(p.Extensions[k])
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte(',')
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Write writes p to w as a problem+json response.
+func (p *Problem) Write(w http.ResponseWriter) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	w.Write(b)
+}
@@ -0,0 +1,42 @@
+package errors
+
+// Key is a typed key of the data attached to errors. It stores values in Data under its name, so they are rendered
+// and marshalled like any other data, while providing compile-time type safety to the code setting and reading them.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new Key for values of type T stored under name.
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+// Name returns the name the values of k are stored under.
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// From returns the value of k held by the nearest error in the chain of err, as Lookup does. It returns false if no
+// error holds k or if the value is not of type T.
+func (k Key[T]) From(err error) (T, bool) {
+	var zero T
+
+	value, ok := Lookup(err, k.name)
+	if !ok {
+		return zero, false
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		return zero, false
+	}
+
+	return v, true
+}
+
+// With returns a copy of err with the value of key set in its data if it is an Err, *Err or a composition of them.
+// Errors of other types are wrapped by a new *Err holding the value and no message of its own, so its Error method
+// returns the message of err. The provided error is never modified.
+func With[T any](err error, key Key[T], value T) error {
+	return withData(err, Data{key.name: value}, nil, 4)
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	keyUserID   = NewKey[string]("userId")
+	keyAttempts = NewKey[int]("attempts")
+)
+
+func TestKey(t *testing.T) {
+	t.Run("when a value is set with With, From should return it with its type", func(t *testing.T) {
+		err := With(New("failed"), keyUserID, "42")
+		err = With(err, keyAttempts, 3)
+
+		userID, ok := keyUserID.From(Wrap(err, "wrapped"))
+		if !ok || userID != "42" {
+			t.Errorf(`unexpected value, got "%v", expected "%s"`, userID, "42")
+		}
+
+		attempts, ok := keyAttempts.From(err)
+		if !ok || attempts != 3 {
+			t.Errorf("unexpected value, got %v, expected %d", attempts, 3)
+		}
+	})
+
+	t.Run("when the value stored under the key name has another type, From should return false", func(t *testing.T) {
+		err := Errord(Data{"attempts": "three"}, "failed")
+
+		if _, ok := keyAttempts.From(err); ok {
+			t.Errorf("expected From to return false, got true")
+		}
+	})
+
+	t.Run("when With is provided with a standard error, it should wrap it and keep its message", func(t *testing.T) {
+		err := With(io.EOF, keyUserID, "42")
+
+		if err.Error() != io.EOF.Error() {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, err.Error(), io.EOF.Error())
+		}
+
+		if !Is(err, io.EOF) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if userID, ok := keyUserID.From(err); !ok || userID != "42" {
+			t.Errorf(`unexpected value, got "%v", expected "%s"`, userID, "42")
+		}
+	})
+
+	t.Run("when With wraps a standard error, the stack should start at the caller of With", func(t *testing.T) {
+		err := With(io.EOF, keyUserID, "42").(*Err)
+
+		if frames := err.Stack.Frames(); !strings.Contains(frames[0].Function, "TestKey") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestKey")
+		}
+	})
+
+	t.Run("when a value is set with With, MarshalJSON should marshal it like any other data", func(t *testing.T) {
+		err := With(Errord(Data{"server": "db-server-01"}, "failed"), keyUserID, "42")
+
+		b, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		var errs []map[string]any
+		if jsonErr := json.Unmarshal(b, &errs); jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		expected := `map[server:db-server-01 userId:42]`
+		if got := fmt.Sprint(errs[0]["data"]); got != expected {
+			t.Errorf(`unexpected data, got "%s", expected "%s"`, got, expected)
+		}
+	})
+
+	t.Run("when With is provided with nil, it should return nil", func(t *testing.T) {
+		if err := With(nil, keyUserID, "42"); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+}
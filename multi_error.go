@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // NewMulti returns a new errors.MultiError with the provided errs.
 func NewMulti(errs ...error) error {
@@ -9,7 +12,8 @@ func NewMulti(errs ...error) error {
 	}
 }
 
-// AppendMulti appends err to multi.Errors and returns an error if multi is not of the type errors.MultiError.
+// AppendMulti appends err to multi.Errors and returns an error if multi is not of the type errors.MultiError. It is not
+// safe for concurrent use, use a Collector to gather errors from multiple goroutines.
 func AppendMulti(multi error, err error) error {
 	m, ok := multi.(*MultiError)
 	if !ok {
@@ -26,6 +30,12 @@ type MultiError struct {
 	Errors []error
 }
 
+// multiUnwrapper is implemented by errors wrapping multiple errors, such as MultiError and the errors returned by the
+// standard library errors.Join.
+type multiUnwrapper interface {
+	Unwrap() []error
+}
+
 func (m MultiError) Error() string {
 	if len(m.Errors) == 0 {
 		return ""
@@ -33,3 +43,49 @@ func (m MultiError) Error() string {
 
 	return fmt.Sprintf("first of %d errors: %s", len(m.Errors), m.Errors[0].Error())
 }
+
+// Unwrap returns the errors held by m, which allows Is and As to look for matches in every one of them.
+func (m MultiError) Unwrap() []error {
+	return m.Errors
+}
+
+// Format implements fmt.Formatter. The '+v' format renders every error held by m using the Formatter returned by
+// CurrentFormatter, the '#v' format renders GoString and the 'v', 's', 'q', 'x' and 'X' formats render the error
+// message honoring the width, precision and flags.
+func (m MultiError) Format(s fmt.State, verb rune) {
+	formatVerb(s, verb, m, m.GoString)
+}
+
+// GoString implements fmt.GoStringer. It returns a Go-syntax representation of m and the chain of every error it holds.
+func (m MultiError) GoString() string {
+	return goStringMulti(m)
+}
+
+// MarshalJSON implements json.Marshaler. Every error held by m is marshalled with its full chain under "causes".
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toMapsSlice(m))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It restores the errors held by a MultiError marshalled by MarshalJSON.
+func (m *MultiError) UnmarshalJSON(b []byte) error {
+	var entries []errEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+
+	if len(entries) != 1 || entries[0].Causes == nil {
+		return New("the JSON is supposed to hold a single entry with the causes of an errors.MultiError value")
+	}
+
+	errs := make([]error, 0, len(entries[0].Causes))
+	for _, childEntries := range entries[0].Causes {
+		child, err := fromEntries(childEntries)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, child)
+	}
+	m.Errors = errs
+
+	return nil
+}
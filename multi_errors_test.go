@@ -1,6 +1,13 @@
 package errors
 
-import "testing"
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
 
 func TestNewMulti(t *testing.T) {
 	t.Run("when NewMulti is provided with 3 errors, it should return a new error with the message indicating the number of errors and highlighting the first error", func(t *testing.T) {
@@ -35,3 +42,237 @@ func TestAppendMulti(t *testing.T) {
 		}
 	})
 }
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	t.Run("when Is is provided with a multi error holding the target, it should return true", func(t *testing.T) {
+		multiErr := NewMulti(New("failed 1"), Wrap(io.EOF, "failed 2"))
+
+		if !Is(multiErr, io.EOF) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if Is(multiErr, io.ErrUnexpectedEOF) {
+			t.Errorf("expected Is to return false, got true")
+		}
+	})
+
+	t.Run("when As is provided with a multi error holding an error of the target type, it should set the target", func(t *testing.T) {
+		multiErr := Wrap(NewMulti(stderrors.New("failed 1"), customErr{msg: "failed 2"}), "failed")
+
+		var target customErr
+		if !As(multiErr, &target) {
+			t.Fatalf("expected As to return true, got false")
+		}
+
+		if target.msg != "failed 2" {
+			t.Errorf(`unexpected target, got "%s", expected "%s"`, target.msg, "failed 2")
+		}
+	})
+
+	t.Run("when an Err wrapping multiple errors is formatted with %+v, it should format each one of them", func(t *testing.T) {
+		err := Wrap(stderrors.Join(New("failed 1"), stderrors.New("failed 2")), "failed")
+
+		outputStr := fmt.Sprintf("%+v", err)
+		for _, expected := range []string{"causes:", "1:", "\"failed 1\"", "\t\t2:\n\t\t\tfailed 2"} {
+			if !strings.Contains(outputStr, expected) {
+				t.Errorf(`expected "%s" to be in the output string, got "%v"`, expected, outputStr)
+			}
+		}
+	})
+
+	t.Run("when an Err wrapping multiple errors is marshaled, it should marshal each one of them", func(t *testing.T) {
+		err := Wrap(NewMulti(New("failed 1"), stderrors.New("failed 2")), "failed")
+
+		b, jsonErr := json.Marshal(err)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		var errs []struct {
+			Message string `json:"message"`
+			Causes  [][]struct {
+				Message string `json:"message"`
+			} `json:"causes"`
+		}
+		if jsonErr := json.Unmarshal(b, &errs); jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		if len(errs) != 2 {
+			t.Fatalf("unexpected number of errors, got %d, expected %d", len(errs), 2)
+		}
+
+		if len(errs[1].Causes) != 2 {
+			t.Fatalf("unexpected number of causes, got %d, expected %d", len(errs[1].Causes), 2)
+		}
+
+		if errs[1].Causes[0][0].Message != "failed 1" || errs[1].Causes[1][0].Message != "failed 2" {
+			t.Errorf("unexpected causes, got %+v", errs[1].Causes)
+		}
+
+		restored, jsonErr := FromJSON(b)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		if restored.Error() != err.Error() {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, restored.Error(), err.Error())
+		}
+
+		causes := restored.Cause.(multiUnwrapper).Unwrap()
+		if len(causes) != 2 {
+			t.Fatalf("unexpected number of restored causes, got %d, expected %d", len(causes), 2)
+		}
+
+		if e, ok := causes[0].(*Err); !ok || e.Message != "failed 1" {
+			t.Errorf("unexpected restored cause, got %v", causes[0])
+		}
+	})
+}
+
+func TestMultiErrorFormat(t *testing.T) {
+	t.Run("when a multi error is formatted with %+v, it should format every error it holds", func(t *testing.T) {
+		multiErr := NewMulti(
+			Errord(Data{"id": 1}, "failed 1"),
+			Wrap(New("inner failure"), "failed 2"),
+			stderrors.New("failed 3"),
+		)
+
+		outputStr := fmt.Sprintf("%+v", multiErr)
+		expected := []string{
+			"message:\n\t\"first of 3 errors: failed 1\"\ncauses:",
+			"\t1:\n\t\tmessage:\n\t\t\t\"failed 1\"\n\t\tdata:\n\t\t\tid: 1\n\t\tstack:",
+			"\t2:\n\t\tmessage:\n\t\t\t\"failed 2\"",
+			"\t\tcause:\n\t\t\tmessage:\n\t\t\t\t\"inner failure\"",
+			"\t3:\n\t\tfailed 3",
+		}
+		for _, e := range expected {
+			if !strings.Contains(outputStr, e) {
+				t.Errorf(`expected "%s" to be in the output string, got "%v"`, e, outputStr)
+			}
+		}
+	})
+
+	t.Run("when a multi error is formatted with %s, it should only highlight the first error", func(t *testing.T) {
+		multiErr := NewMulti(New("failed 1"), New("failed 2"))
+
+		expected := "first of 2 errors: failed 1"
+		if got := fmt.Sprintf("%s", multiErr); got != expected {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, got, expected)
+		}
+	})
+
+	t.Run("when a multi error is formatted with %q and a width, it should quote and pad the message", func(t *testing.T) {
+		multiErr := NewMulti(&Err{Message: "failed 1"}, &Err{Message: "failed 2"})
+
+		expected := `"first of 2 errors: failed 1"   |`
+		if got := fmt.Sprintf("%-32q|", multiErr); got != expected {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, got, expected)
+		}
+	})
+
+	t.Run("when a multi error is formatted with %#v, it should render every error it holds in Go syntax", func(t *testing.T) {
+		multiErr := NewMulti(&Err{Message: "failed 1", Code: "FAILED"}, stderrors.New("failed 2"))
+
+		expected := `errors.MultiError{Errors:[]error{errors.Err{Message:"failed 1", Code:"FAILED"}, &errors.errorString{s:"failed 2"}}}`
+		if got := fmt.Sprintf("%#v", multiErr); got != expected {
+			t.Errorf(`wrong output, got "%s", expected "%s"`, got, expected)
+		}
+	})
+}
+
+func TestMultiErrorJSONMarshaling(t *testing.T) {
+	t.Run("when a struct embedding a MultiError value is marshaled, it should keep the fields of the struct", func(t *testing.T) {
+		b, err := json.Marshal(struct {
+			MultiError
+			Op string
+		}{MultiError{Errors: []error{&Err{Message: "failed 1"}}}, "write"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `{"Errors":[[{"message":"failed 1","stack":null}]],"Op":"write"}`
+		if string(b) != expected {
+			t.Errorf(`unexpected JSON, got "%s", expected "%s"`, b, expected)
+		}
+	})
+
+	t.Run("when a multi error is marshaled, it should embed the full chain of every error it holds", func(t *testing.T) {
+		multiErr := NewMulti(
+			Errord(Data{"id": 1}, "failed 1"),
+			Wrap(New("inner failure"), "failed 2"),
+			stderrors.New("failed 3"),
+		)
+
+		b, err := json.Marshal(multiErr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var errs []struct {
+			Message string             `json:"message"`
+			Causes  [][]map[string]any `json:"causes"`
+		}
+		if err := json.Unmarshal(b, &errs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(errs) != 1 {
+			t.Fatalf("unexpected number of errors, got %d, expected %d", len(errs), 1)
+		}
+
+		if errs[0].Message != multiErr.Error() {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, errs[0].Message, multiErr.Error())
+		}
+
+		if len(errs[0].Causes) != 3 {
+			t.Fatalf("unexpected number of causes, got %d, expected %d", len(errs[0].Causes), 3)
+		}
+
+		if _, ok := errs[0].Causes[0][0]["data"]; !ok {
+			t.Errorf("unexpected data, got undefined key, expected %v", Data{"id": 1})
+		}
+
+		if _, ok := errs[0].Causes[0][0]["stack"]; !ok {
+			t.Errorf("unexpected stack, got undefined key, expected a stack")
+		}
+
+		if len(errs[0].Causes[1]) != 2 {
+			t.Errorf("unexpected length of the second chain, got %d, expected %d", len(errs[0].Causes[1]), 2)
+		}
+
+		if fmt.Sprint(errs[0].Causes[2][0]["message"]) != "failed 3" {
+			t.Errorf(`unexpected error message, got "%v", expected "%s"`, errs[0].Causes[2][0]["message"], "failed 3")
+		}
+	})
+
+	t.Run("when a marshaled multi error is unmarshaled, it should restore every error it holds", func(t *testing.T) {
+		multiErr := NewMulti(New("failed 1"), Wrap(New("inner failure"), "failed 2"))
+
+		b, err := json.Marshal(multiErr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var restored MultiError
+		if err := json.Unmarshal(b, &restored); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if restored.Error() != multiErr.Error() {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, restored.Error(), multiErr.Error())
+		}
+
+		if len(restored.Errors) != 2 {
+			t.Fatalf("unexpected number of errors, got %d, expected %d", len(restored.Errors), 2)
+		}
+
+		if got := restored.Errors[1].Error(); got != "failed 2: inner failure" {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, got, "failed 2: inner failure")
+		}
+
+		if _, err := FromJSON(b); err == nil {
+			t.Errorf("expected FromJSON to fail for a multi error, got nil")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// PanicError is the error created from a recovered panic. It preserves the value passed to panic, and its embedded Err
+// holds the stack trace at the point where the panic happened. If the value is an error, it is the cause of the
+// PanicError.
+type PanicError struct {
+	*Err
+	Value any
+}
+
+// NewPanicError returns a *PanicError for the value returned by recover. It must be called by the deferred function
+// that recovered the panic, so the stack trace starts at the function that panicked.
+func NewPanicError(v any) error {
+	e := &Err{Stack: panicStack()}
+	if cause, ok := v.(error); ok {
+		e.Message = "panic"
+		e.Cause = cause
+	} else {
+		e.Message = fmt.Sprintf("panic: %v", v)
+	}
+
+	return &PanicError{Err: e, Value: v}
+}
+
+// As allows As to find the embedded *Err of p.
+func (p *PanicError) As(target any) bool {
+	if t, ok := target.(**Err); ok {
+		*t = p.Err
+		return true
+	}
+
+	return false
+}
+
+// Recover recovers a panic and stores it in err as a *PanicError. It must be deferred directly, e.g.
+// defer errors.Recover(&err), and it leaves err untouched if there is no panic.
+func Recover(err *error) {
+	if v := recover(); v != nil {
+		*err = NewPanicError(v)
+	}
+}
+
+// Go runs f in a new goroutine, recovering a panic as a *PanicError. The returned channel receives the *PanicError, or
+// nil if f returns normally, and is closed afterwards.
+func Go(f func()) <-chan error {
+	errc := make(chan error, 1)
+	go func() {
+		var err error
+		defer func() {
+			errc <- err
+			close(errc)
+		}()
+		defer Recover(&err)
+
+		f()
+	}()
+	return errc
+}
+
+// panicStack returns a stack trace of the calling goroutine starting at the function that panicked. It is supposed to
+// be called by NewPanicError while panicking, otherwise the stack trace starts at the caller of NewPanicError.
+func panicStack() Stack {
+	st := callersSkip(4)
+	for i, entry := range st {
+		if fn := runtime.FuncForPC(entry.pc); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+
+		st = st[i+1:]
+		// runtime errors, such as nil pointer dereferences, are raised by runtime functions called on behalf of the
+		// function that panicked
+		for len(st) > 0 {
+			if fn := runtime.FuncForPC(st[0].pc); fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+			st = st[1:]
+		}
+		break
+	}
+	return st
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// panickingFunc panics with v.
+//
+//go:noinline
+func panickingFunc(v any) {
+	panic(v)
+}
+
+// recoverPanic calls panickingFunc and recovers its panic using Recover.
+func recoverPanic(v any) (err error) {
+	defer Recover(&err)
+	panickingFunc(v)
+	return nil
+}
+
+// nilPointerFunc dereferences a nil pointer.
+//
+//go:noinline
+func nilPointerFunc(p *int) int {
+	return *p
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("when a function panics, Recover should store a PanicError with the panic value and the panic stack", func(t *testing.T) {
+		err := recoverPanic("boom")
+
+		panicErr, ok := err.(*PanicError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *PanicError", err)
+		}
+
+		if panicErr.Value != "boom" {
+			t.Errorf(`unexpected panic value, got "%v", expected "%s"`, panicErr.Value, "boom")
+		}
+
+		if err.Error() != "panic: boom" {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, err.Error(), "panic: boom")
+		}
+
+		frames := panicErr.Stack.Frames()
+		if !strings.HasSuffix(frames[0].Function, ".panickingFunc") {
+			t.Errorf(`unexpected first frame, got "%s", expected a suffix of "%s"`, frames[0].Function, ".panickingFunc")
+		}
+
+		if !strings.HasSuffix(frames[1].Function, ".recoverPanic") {
+			t.Errorf(`unexpected second frame, got "%s", expected a suffix of "%s"`, frames[1].Function, ".recoverPanic")
+		}
+
+		if outputStr := fmt.Sprintf("%+v", err); !strings.Contains(outputStr, "panickingFunc") {
+			t.Errorf(`expected "panickingFunc" to be in the output string, got "%v"`, outputStr)
+		}
+	})
+
+	t.Run("when a function panics with an error, the PanicError should wrap it", func(t *testing.T) {
+		err := recoverPanic(io.EOF)
+
+		if !Is(err, io.EOF) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if err.Error() != "panic: EOF" {
+			t.Errorf(`unexpected error message, got "%s", expected "%s"`, err.Error(), "panic: EOF")
+		}
+	})
+
+	t.Run("when a function panics with a runtime error, the stack should start at the function that panicked", func(t *testing.T) {
+		var err error
+		func() {
+			defer Recover(&err)
+			nilPointerFunc(nil)
+		}()
+
+		panicErr, ok := err.(*PanicError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *PanicError", err)
+		}
+
+		if frames := panicErr.Stack.Frames(); !strings.HasSuffix(frames[0].Function, ".nilPointerFunc") {
+			t.Errorf(`unexpected first frame, got "%s", expected a suffix of "%s"`, frames[0].Function, ".nilPointerFunc")
+		}
+	})
+
+	t.Run("when a function does not panic, Recover should leave the error untouched", func(t *testing.T) {
+		err := func() (err error) {
+			defer Recover(&err)
+			return io.EOF
+		}()
+
+		if err != io.EOF {
+			t.Errorf("unexpected error, got %v, expected %v", err, io.EOF)
+		}
+	})
+}
+
+func TestGo(t *testing.T) {
+	t.Run("when the function run by Go panics, the channel should receive a PanicError", func(t *testing.T) {
+		err := <-Go(func() { panickingFunc("boom") })
+
+		panicErr, ok := err.(*PanicError)
+		if !ok {
+			t.Fatalf("unexpected error type, got %T, expected *PanicError", err)
+		}
+
+		if panicErr.Value != "boom" {
+			t.Errorf(`unexpected panic value, got "%v", expected "%s"`, panicErr.Value, "boom")
+		}
+
+		if frames := panicErr.Stack.Frames(); !strings.HasSuffix(frames[0].Function, ".panickingFunc") {
+			t.Errorf(`unexpected first frame, got "%s", expected a suffix of "%s"`, frames[0].Function, ".panickingFunc")
+		}
+	})
+
+	t.Run("when the function run by Go returns normally, the channel should receive nil and be closed", func(t *testing.T) {
+		errc := Go(func() {})
+
+		if err := <-errc; err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+
+		if _, ok := <-errc; ok {
+			t.Errorf("expected the channel to be closed")
+		}
+	})
+}
+
+func TestPanicErrorAs(t *testing.T) {
+	t.Run("when As is provided with a PanicError and an *Err target, it should set the embedded *Err", func(t *testing.T) {
+		err := Wrap(recoverPanic("boom"), "request failed")
+
+		var target *Err
+		if !As(err.(*Err).Cause, &target) {
+			t.Fatalf("expected As to return true, got false")
+		}
+
+		if target.Message != "panic: boom" {
+			t.Errorf(`unexpected message, got "%s", expected "%s"`, target.Message, "panic: boom")
+		}
+	})
+}
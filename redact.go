@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+)
+
+// RedactedValue is what redacted values are rendered as.
+const RedactedValue = "[REDACTED]"
+
+// Secret wraps a value that must never be rendered, such as a token or a password. It is rendered as RedactedValue by
+// every fmt verb, MarshalJSON and log/slog, while Value gives access to the wrapped value.
+type Secret struct {
+	value any
+}
+
+// NewSecret returns a Secret wrapping v.
+func NewSecret(v any) Secret {
+	return Secret{value: v}
+}
+
+// Value returns the wrapped value.
+func (s Secret) Value() any {
+	return s.value
+}
+
+// String returns RedactedValue.
+func (s Secret) String() string {
+	return RedactedValue
+}
+
+// Format implements fmt.Formatter. Every verb renders RedactedValue.
+func (s Secret) Format(f fmt.State, verb rune) {
+	io.WriteString(f, RedactedValue)
+}
+
+// MarshalJSON implements json.Marshaler. It marshals RedactedValue.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedValue)
+}
+
+// LogValue implements slog.LogValuer. It returns RedactedValue.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+// RedactionPolicy defines the data keys whose values are redacted by the %+v format, MarshalJSON and the log/slog
+// integration.
+type RedactionPolicy struct {
+	// Keys holds the exact names of the redacted keys.
+	Keys []string
+	// Patterns holds the regular expressions matching the names of the redacted keys.
+	Patterns []*regexp.Regexp
+}
+
+// redactionPolicy is the current RedactionPolicy.
+var redactionPolicy atomic.Pointer[RedactionPolicy]
+
+// SetRedactionPolicy sets the policy defining the data keys whose values are redacted. Values wrapped by Secret are
+// always redacted, regardless of the policy.
+func SetRedactionPolicy(p RedactionPolicy) {
+	redactionPolicy.Store(&p)
+}
+
+// matches reports whether the values of key are redacted by p.
+func (p *RedactionPolicy) matches(key string) bool {
+	if p == nil {
+		return false
+	}
+
+	for _, k := range p.Keys {
+		if k == key {
+			return true
+		}
+	}
+
+	for _, pattern := range p.Patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redacted returns a copy of d where the values of the keys matched by the redaction policy, as well as the values
+// wrapped by Secret, are replaced by RedactedValue.
+func (d Data) Redacted() Data {
+	if d == nil {
+		return nil
+	}
+
+	policy := redactionPolicy.Load()
+
+	redacted := make(Data, len(d))
+	for k, v := range d {
+		redacted[k] = v
+		if _, ok := v.(Secret); ok || policy.matches(k) {
+			redacted[k] = RedactedValue
+		}
+	}
+
+	return redacted
+}
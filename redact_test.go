@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// renderings returns every rendering of err that could leak its data.
+func renderings(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	b, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("unexpected error: %v", jsonErr)
+	}
+
+	var buf bytes.Buffer
+	slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil))).Error("failed", "error", fmt.Errorf("wrapped: %w", err))
+
+	return map[string]string{
+		"%+v":  fmt.Sprintf("%+v", err),
+		"%#v":  fmt.Sprintf("%#v", err),
+		"%v":   fmt.Sprintf("%v", err),
+		"JSON": string(b),
+		"slog": buf.String(),
+	}
+}
+
+func TestRedaction(t *testing.T) {
+	t.Run("when a value is wrapped by Secret, it should never be rendered", func(t *testing.T) {
+		err := Wrapd(New("invalid credentials"), Data{"token": NewSecret("s3cr3t-t0k3n"), "userId": "42"}, "failed to authenticate")
+
+		for name, output := range renderings(t, err) {
+			if strings.Contains(output, "s3cr3t-t0k3n") {
+				t.Errorf(`expected the secret not to be in the %s output, got "%s"`, name, output)
+			}
+		}
+
+		output := fmt.Sprintf("%+v", err)
+		if !strings.Contains(output, "token: "+RedactedValue) || !strings.Contains(output, "userId: 42") {
+			t.Errorf(`expected the token to be redacted and the userId to be kept, got "%s"`, output)
+		}
+
+		if got := err.(*Err).Data["token"].(Secret).Value(); got != "s3cr3t-t0k3n" {
+			t.Errorf(`unexpected secret value, got "%v", expected "%s"`, got, "s3cr3t-t0k3n")
+		}
+	})
+
+	t.Run("when a Secret is formatted directly, it should be redacted for every verb", func(t *testing.T) {
+		secret := NewSecret("s3cr3t")
+		for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d"} {
+			if got := fmt.Sprintf(verb, secret); got != RedactedValue {
+				t.Errorf(`unexpected output for %s, got "%s", expected "%s"`, verb, got, RedactedValue)
+			}
+		}
+	})
+
+	t.Run("when the redaction policy matches keys by name or pattern, their values should never be rendered", func(t *testing.T) {
+		defer SetRedactionPolicy(RedactionPolicy{})
+		SetRedactionPolicy(RedactionPolicy{
+			Keys:     []string{"email"},
+			Patterns: []*regexp.Regexp{regexp.MustCompile(`(?i)password`)},
+		})
+
+		err := NewData().Set("email", "jane@example.com").Set("userId", "42").Attach(
+			Wrap(Errord(Data{"dbPassword": "hunter2"}, "connection refused"), "failed to create the user"),
+		)
+
+		for name, output := range renderings(t, err) {
+			for _, secret := range []string{"jane@example.com", "hunter2"} {
+				if strings.Contains(output, secret) {
+					t.Errorf(`expected "%s" not to be in the %s output, got "%s"`, secret, name, output)
+				}
+			}
+		}
+
+		output := fmt.Sprintf("%+v", err)
+		if !strings.Contains(output, "data:\n\temail: "+RedactedValue+"\n\tuserId: 42") {
+			t.Errorf(`expected the email to be redacted in insertion order, got "%s"`, output)
+		}
+
+		if got := err.(*Err).Data["email"]; got != "jane@example.com" {
+			t.Errorf(`unexpected raw value, got "%v", expected "%s"`, got, "jane@example.com")
+		}
+	})
+
+	t.Run("when an Err value or a struct embedding one is formatted, the redaction policy should apply to every verb", func(t *testing.T) {
+		defer SetRedactionPolicy(RedactionPolicy{})
+		SetRedactionPolicy(RedactionPolicy{Patterns: []*regexp.Regexp{regexp.MustCompile(`(?i)password`)}})
+
+		value := Err{Message: "connection refused", Data: Data{"password": "hunter2"}, Stack: Callers(0)}
+		values := map[string]any{
+			"Err":         value,
+			"composition": valueComposition{Err: value, ID: 1},
+		}
+
+		for name, v := range values {
+			for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%d"} {
+				if output := fmt.Sprintf(verb, v); strings.Contains(output, "hunter2") || strings.Contains(output, "stackEntry") {
+					t.Errorf(`expected the data and stack to be rendered safely for %s with %s, got "%s"`, name, verb, output)
+				}
+			}
+		}
+
+		if output := fmt.Sprintf("%#v", value); !strings.Contains(output, `"password":"`+RedactedValue+`"`) {
+			t.Errorf(`expected the password to be redacted, got "%s"`, output)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package errors
+
+// Sentinel is an error meant to be declared at package level and compared with Is, e.g.
+//
+//	var ErrNotFound = errors.NewSentinel("not found")
+//
+// Unlike the errors returned by New, a Sentinel holds no stack trace and is never modified, so it is safe to share
+// between goroutines. Sentinels are matched by identity, so two sentinels with the same message are distinct errors.
+// Use Raise to return it with a stack trace pointing at the caller.
+type Sentinel struct {
+	msg string
+}
+
+// NewSentinel returns a new *Sentinel with the provided message.
+func NewSentinel(msg string) *Sentinel {
+	return &Sentinel{msg: msg}
+}
+
+func (s *Sentinel) Error() string {
+	return s.msg
+}
+
+// Raise returns a new error with a stack trace wrapping s, for which Is reports true when matched against s. The
+// sentinel itself is never modified, so it can be raised concurrently from multiple goroutines.
+func Raise(s *Sentinel) error {
+	return &Err{
+		Stack: callers(),
+		Cause: s,
+	}
+}
+
+// Raised returns a new error with a stack trace and additional data wrapping s, for which Is reports true when matched
+// against s.
+func Raised(s *Sentinel, data Data) error {
+	return &Err{
+		Data:  data,
+		Stack: callers(),
+		Cause: s,
+	}
+}
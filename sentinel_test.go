@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var errNotFound = NewSentinel("not found")
+
+func TestRaise(t *testing.T) {
+	t.Run("when a sentinel is raised, it should return a new error matching the sentinel", func(t *testing.T) {
+		err := Raise(errNotFound)
+
+		if !Is(err, errNotFound) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if err.Error() != "not found" {
+			t.Errorf(`wrong error message, got "%s", expected "%s"`, err.Error(), "not found")
+		}
+
+		if Raise(errNotFound) == err {
+			t.Errorf("expected every raised error to be a new error")
+		}
+	})
+
+	t.Run("when a sentinel is raised, the stack should point at the caller of Raise", func(t *testing.T) {
+		frames := Raise(errNotFound).(*Err).Stack.Frames()
+
+		if !strings.Contains(frames[0].Function, "TestRaise") {
+			t.Errorf(`unexpected function, got "%s", expected it to contain "%s"`, frames[0].Function, "TestRaise")
+		}
+	})
+
+	t.Run("when a raised sentinel is wrapped, Is should still match the sentinel", func(t *testing.T) {
+		err := Wrap(Raised(errNotFound, Data{"id": 1}), "failed to load the user")
+
+		if !Is(err, errNotFound) {
+			t.Errorf("expected Is to return true, got false")
+		}
+
+		if Is(err, NewSentinel("another")) {
+			t.Errorf("expected Is to return false, got true")
+		}
+
+		if got, _ := LookupInt(err, "id"); got != 1 {
+			t.Errorf("unexpected data, got %v, expected %v", got, 1)
+		}
+	})
+
+	t.Run("when two sentinels hold the same message, Is should not match one against the other", func(t *testing.T) {
+		err := Raise(errNotFound)
+
+		if Is(err, NewSentinel("not found")) {
+			t.Errorf("expected Is to return false, got true")
+		}
+	})
+
+	t.Run("when a raised sentinel is marshaled, it should keep the sentinel message", func(t *testing.T) {
+		b, err := json.Marshal(Raise(errNotFound))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var errs []map[string]any
+		if err := json.Unmarshal(b, &errs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(errs) != 2 || errs[1]["message"] != "not found" {
+			t.Errorf("unexpected errors, got %v", errs)
+		}
+	})
+
+}
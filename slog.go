@@ -0,0 +1,148 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+)
+
+// LogValue implements slog.LogValuer. It returns a group holding the message, data, stack and cause chain of e.
+func (e Err) LogValue() slog.Value {
+	return errLogValue(e)
+}
+
+// logValue returns a group representing err and its causes. Err and *Err values, as well as StackTracer and
+// DataProvider values, are represented by their message, data, stack and cause, errors wrapping multiple errors by
+// their message and numbered causes, and the remaining errors by their message and cause.
+func logValue(err error) slog.Value {
+	if e, ok := toErr(err); ok {
+		return errLogValue(e)
+	}
+
+	switch e := err.(type) {
+	case multiUnwrapper:
+		attrs := []slog.Attr{slog.String("message", err.Error())}
+		causes := make([]slog.Attr, 0)
+		for _, child := range e.Unwrap() {
+			if !isNil(child) {
+				causes = append(causes, slog.Attr{Key: strconv.Itoa(len(causes) + 1), Value: logValue(child)})
+			}
+		}
+		if len(causes) > 0 {
+			attrs = append(attrs, slog.Attr{Key: "causes", Value: slog.GroupValue(causes...)})
+		}
+		return slog.GroupValue(attrs...)
+	default:
+		attrs := []slog.Attr{slog.String("message", err.Error())}
+		if cause := unwrap(err); cause != nil {
+			attrs = append(attrs, slog.Attr{Key: "cause", Value: logValue(cause)})
+		}
+		return slog.GroupValue(attrs...)
+	}
+}
+
+// errLogValue returns a group holding the message, data, stack and cause chain of e.
+func errLogValue(e Err) slog.Value {
+	attrs := []slog.Attr{slog.String("message", e.Message)}
+
+	if e.Code != "" {
+		attrs = append(attrs, slog.String("code", string(e.Code)))
+	}
+
+	if e.Data != nil {
+		redacted := e.Data.Redacted()
+		data := make([]slog.Attr, 0, len(redacted))
+		for _, k := range redacted.Keys(e.DataOrder...) {
+			data = append(data, slog.Any(k, redacted[k]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "data", Value: slog.GroupValue(data...)})
+	}
+
+	if len(e.Stack) > 0 {
+		attrs = append(attrs, slog.Any("stack", e.Stack.Strings()))
+	}
+
+	if e.Cause != nil {
+		attrs = append(attrs, slog.Attr{Key: "cause", Value: logValue(e.Cause)})
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// containsErr reports whether err or any error in its chain is an Err, *Err, StackTracer or DataProvider.
+func containsErr(err error) bool {
+	if _, ok := toErr(err); ok {
+		return true
+	}
+
+	switch e := err.(type) {
+	case nil:
+		return false
+	case multiUnwrapper:
+		for _, child := range e.Unwrap() {
+			if containsErr(child) {
+				return true
+			}
+		}
+		return false
+	default:
+		return containsErr(unwrap(err))
+	}
+}
+
+// slogHandler is the slog.Handler returned by NewSlogHandler.
+type slogHandler struct {
+	handler slog.Handler
+}
+
+// NewSlogHandler returns a slog.Handler that passes records to h after expanding every error attribute holding an Err
+// or *Err in its chain into a group with the message, data, stack and cause chain of the error.
+func NewSlogHandler(h slog.Handler) slog.Handler {
+	return &slogHandler{handler: h}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(expandAttr(a))
+		return true
+	})
+
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	expanded := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		expanded[i] = expandAttr(a)
+	}
+
+	return &slogHandler{handler: h.handler.WithAttrs(expanded)}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{handler: h.handler.WithGroup(name)}
+}
+
+// expandAttr expands a into a group if it holds an error with an Err or *Err in its chain, looking into groups.
+func expandAttr(a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		expanded := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			expanded[i] = expandAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(expanded...)}
+	case slog.KindAny, slog.KindLogValuer:
+		if err, ok := a.Value.Any().(error); ok && containsErr(err) {
+			return slog.Attr{Key: a.Key, Value: logValue(err)}
+		}
+	}
+
+	return a
+}
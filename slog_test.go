@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+// logEntry logs msg with the provided attributes using a JSON handler, optionally wrapped by NewSlogHandler, and
+// returns the decoded entry.
+func logEntry(t *testing.T, wrap bool, msg string, args ...any) map[string]any {
+	t.Helper()
+
+	var buf bytes.Buffer
+	var h slog.Handler = slog.NewJSONHandler(&buf, nil)
+	if wrap {
+		h = NewSlogHandler(h)
+	}
+	slog.New(h).Error(msg, args...)
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return entry
+}
+
+func TestLogValue(t *testing.T) {
+	t.Run("when an Err is logged with slog, it should be logged as a group with its message, data, stack and cause chain", func(t *testing.T) {
+		err := Wrapd(New("context timeout"), Data{"server": "db-server-01"}, "failed to connect to the database")
+
+		entry := logEntry(t, false, "request failed", "error", err)
+
+		errGroup, ok := entry["error"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected error attribute, got %v, expected a group", entry["error"])
+		}
+
+		if errGroup["message"] != "failed to connect to the database" {
+			t.Errorf(`unexpected message, got "%v", expected "%s"`, errGroup["message"], "failed to connect to the database")
+		}
+
+		if data, ok := errGroup["data"].(map[string]any); !ok || data["server"] != "db-server-01" {
+			t.Errorf("unexpected data, got %v, expected %v", errGroup["data"], Data{"server": "db-server-01"})
+		}
+
+		if stack, ok := errGroup["stack"].([]any); !ok || len(stack) == 0 {
+			t.Errorf("unexpected stack, got %v, expected a non-empty array", errGroup["stack"])
+		}
+
+		cause, ok := errGroup["cause"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected cause, got %v, expected a group", errGroup["cause"])
+		}
+
+		if cause["message"] != "context timeout" {
+			t.Errorf(`unexpected message, got "%v", expected "%s"`, cause["message"], "context timeout")
+		}
+	})
+}
+
+func TestSlogHandler(t *testing.T) {
+	t.Run("when an error wrapping an Err is logged through the handler, it should be expanded", func(t *testing.T) {
+		err := fmt.Errorf("request failed: %w", Errord(Data{"id": 1}, "context timeout"))
+
+		entry := logEntry(t, true, "request failed", slog.Group("request", slog.Any("error", err)))
+
+		request, ok := entry["request"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected request attribute, got %v, expected a group", entry["request"])
+		}
+
+		errGroup, ok := request["error"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected error attribute, got %v, expected a group", request["error"])
+		}
+
+		if errGroup["message"] != err.Error() {
+			t.Errorf(`unexpected message, got "%v", expected "%s"`, errGroup["message"], err.Error())
+		}
+
+		cause, ok := errGroup["cause"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected cause, got %v, expected a group", errGroup["cause"])
+		}
+
+		if data, ok := cause["data"].(map[string]any); !ok || data["id"] != float64(1) {
+			t.Errorf("unexpected data, got %v, expected %v", cause["data"], Data{"id": 1})
+		}
+	})
+
+	t.Run("when an error wrapping multiple errors is logged through the handler, every one of them should be expanded", func(t *testing.T) {
+		err := NewMulti(New("failed 1"), fmt.Errorf("failed 2"))
+
+		entry := logEntry(t, true, "request failed", "error", err)
+
+		errGroup := entry["error"].(map[string]any)
+		causes, ok := errGroup["causes"].(map[string]any)
+		if !ok {
+			t.Fatalf("unexpected causes, got %v, expected a group", errGroup["causes"])
+		}
+
+		if first, ok := causes["1"].(map[string]any); !ok || first["message"] != "failed 1" {
+			t.Errorf("unexpected first cause, got %v", causes["1"])
+		}
+
+		if second, ok := causes["2"].(map[string]any); !ok || second["message"] != "failed 2" {
+			t.Errorf("unexpected second cause, got %v", causes["2"])
+		}
+	})
+
+	t.Run("when an error without an Err in its chain is logged through the handler, it should be left untouched", func(t *testing.T) {
+		err := fmt.Errorf("request failed")
+
+		entry := logEntry(t, true, "request failed", "error", err)
+
+		if entry["error"] != "request failed" {
+			t.Errorf(`unexpected error attribute, got "%v", expected "%s"`, entry["error"], "request failed")
+		}
+	})
+
+	t.Run("when attributes holding an Err are added with With, they should be expanded", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(NewSlogHandler(slog.NewJSONHandler(&buf, nil)))
+		logger.With("error", fmt.Errorf("wrapped: %w", New("failed"))).Info("request failed")
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, ok := entry["error"].(map[string]any); !ok {
+			t.Errorf("unexpected error attribute, got %v, expected a group", entry["error"])
+		}
+	})
+}
@@ -1,27 +1,224 @@
 package errors
 
 import (
+	"encoding/json"
 	"fmt"
 	"runtime"
 )
 
-// Stack represents a stack trace in the form of a slice of strings.
-type Stack []string
+// Stack represents a stack trace in the form of a slice of entries. The entries captured by runtime.Callers only hold
+// a program counter, which is symbolized when the stack is rendered and keeps the creation of errors cheap, while the
+// entries restored by ParseStack hold the frame they were parsed from.
+type Stack []stackEntry
 
-// callers returns a stack trace of the calling goroutine.
+// stackEntry is an entry of a Stack, holding either a program counter or a restored frame.
+type stackEntry struct {
+	pc    uintptr
+	frame *Frame
+}
+
+// equal reports whether both entries represent the same frame.
+func (e stackEntry) equal(other stackEntry) bool {
+	if e.frame == nil || other.frame == nil {
+		return e.frame == nil && other.frame == nil && e.pc == other.pc
+	}
+
+	return *e.frame == *other.frame
+}
+
+// callers returns a stack trace of the goroutine that called the function calling callers.
 func callers() Stack {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(3, pcs[:])
-	var st Stack = make([]string, 0)
-	for i := 0; i < n; i++ {
-		fn := runtime.FuncForPC(pcs[i])
-		if fn == nil {
-			st = append(st, "unknown")
-		} else {
-			file, line := fn.FileLine(pcs[i])
-			st = append(st, fmt.Sprintf("%s @ %s:%d", fn.Name(), file, line))
+	return callersSkip(4)
+}
+
+// Callers returns a stack trace of the calling goroutine, skipping skip frames above the caller of Callers, with 0
+// identifying the caller of Callers. Helper functions can use it to build errors pointing at their own callers. It
+// returns nil if stack capture is disabled.
+func Callers(skip int) Stack {
+	return callersSkip(skip + 3)
+}
+
+// callersSkip returns a stack trace of the calling goroutine honoring the stack capture configuration, with skip
+// being the number of frames to skip as in runtime.Callers.
+func callersSkip(skip int) Stack {
+	if !StackCaptureEnabled() {
+		return nil
+	}
+
+	var buf [defaultStackDepth]uintptr
+	pcs := buf[:]
+	if depth := StackDepth(); depth > len(buf) {
+		pcs = make([]uintptr, depth)
+	} else {
+		pcs = buf[:depth]
+	}
+
+	n := runtime.Callers(skip, pcs)
+	st := make(Stack, n)
+	for i, pc := range pcs[:n] {
+		st[i].pc = pc
+	}
+	return st
+}
+
+// Frames returns the symbolized frames of the stack trace. Inlined calls are expanded into their own frames, so the
+// number of frames may be greater than the number of entries.
+func (s Stack) Frames() []Frame {
+	frames := make([]Frame, 0, len(s))
+	for start := 0; start < len(s); {
+		if s[start].frame != nil {
+			frames = append(frames, *s[start].frame)
+			start++
+			continue
 		}
+
+		pcs := make([]uintptr, 0, len(s)-start)
+		for ; start < len(s) && s[start].frame == nil; start++ {
+			pcs = append(pcs, s[start].pc)
+		}
+
+		callersFrames := runtime.CallersFrames(pcs)
+		for {
+			frame, more := callersFrames.Next()
+			frames = append(frames, newFrame(frame))
+			if !more {
+				break
+			}
+		}
+	}
+	return frames
+}
+
+// Strings returns the symbolized representation of the stack trace, one "function @ file:line" string per frame.
+func (s Stack) Strings() []string {
+	frames := s.Frames()
+	lines := make([]string, 0, len(frames))
+	for _, frame := range frames {
+		lines = append(lines, frame.String())
+	}
+	return lines
+}
+
+// trimmedStrings returns the symbolized representation of the frames of the stack trace that are not shared with
+// the cause stack, followed by a "... N more" line counting the omitted frames.
+func (s Stack) trimmedStrings(cause Stack) []string {
+	common := s.commonSuffix(cause)
+	if common == 0 {
+		return s.Strings()
+	}
+
+	lines := s[:len(s)-common].Strings()
+	return append(lines, fmt.Sprintf("... %d more", len(s[len(s)-common:].Frames())))
+}
+
+// commonSuffix returns the number of trailing entries shared by both stack traces.
+func (s Stack) commonSuffix(other Stack) int {
+	n := 0
+	for n < len(s) && n < len(other) && s[len(s)-1-n].equal(other[len(other)-1-n]) {
+		n++
+	}
+	return n
+}
+
+// stackLines returns the symbolized representation of the stack trace of e, deduplicated against the stack of its
+// cause if stack deduplication is enabled.
+func stackLines(e Err) []string {
+	if StackDedupEnabled() {
+		return e.Stack.trimmedStrings(causeStack(e.Cause))
+	}
+
+	return e.Stack.Strings()
+}
+
+// causeStack returns the stack trace of the nearest error in the chain of err holding one.
+func causeStack(err error) Stack {
+	for ; err != nil; err = unwrap(err) {
+		if st := stackOf(err); len(st) > 0 {
+			return st
+		}
+	}
+	return nil
+}
+
+// stackOf returns the stack trace of err if it is an Err, *Err or a StackTracer.
+func stackOf(err error) Stack {
+	if e, ok := err.(*Err); ok && e == nil {
+		return nil
+	}
+
+	if st, ok := err.(StackTracer); ok {
+		return st.StackTrace()
+	}
+	return nil
+}
+
+// MergedStack returns a single stack trace for the whole chain of err. It starts from the stack of the deepest error
+// in the chain holding one, and inserts the frames of each wrapper that are not shared with it right before the
+// first shared frame, so each function of the chain appears next to the places where it wrapped the error. It
+// returns nil if no error in the chain holds a stack trace.
+func MergedStack(err error) Stack {
+	var stacks []Stack
+	for ; err != nil; err = unwrap(err) {
+		if st := stackOf(err); len(st) > 0 {
+			stacks = append(stacks, st)
+		}
+	}
+
+	if len(stacks) == 0 {
+		return nil
+	}
+
+	merged := append(Stack(nil), stacks[len(stacks)-1]...)
+	for i := len(stacks) - 2; i >= 0; i-- {
+		st := stacks[i]
+		common := st.commonSuffix(merged)
+		at := len(merged) - common
+
+		next := make(Stack, 0, len(merged)+len(st)-common)
+		next = append(next, merged[:at]...)
+		next = append(next, st[:len(st)-common]...)
+		next = append(next, merged[at:]...)
+		merged = next
+	}
+
+	return merged
+}
+
+// MarshalJSON implements json.Marshaler. The stack is marshalled as an array of symbolized frames.
+func (s Stack) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(s.Strings())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It restores a stack marshalled by MarshalJSON as ParseStack does.
+func (s *Stack) UnmarshalJSON(b []byte) error {
+	var lines []string
+	if err := json.Unmarshal(b, &lines); err != nil {
+		return err
+	}
+
+	*s = ParseStack(lines)
+
+	return nil
+}
+
+// ParseStack restores a stack from the strings returned by Stack.Strings, e.g. ones received from another process.
+// The frames it holds can be rendered and inspected just like the ones of a captured stack, but they have no program
+// counters. The frames are held by the returned stack only, so they are released along with it. It returns nil if
+// lines is nil.
+func ParseStack(lines []string) Stack {
+	if lines == nil {
+		return nil
+	}
+
+	frames := make([]Frame, len(lines))
+	st := make(Stack, len(lines))
+	for i, line := range lines {
+		frames[i] = parseFrame(line)
+		st[i].frame = &frames[i]
 	}
 	return st
 }
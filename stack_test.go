@@ -0,0 +1,231 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	t.Run("when an error is created, its stack should only hold program counters until it is symbolized", func(t *testing.T) {
+		err := New("error message").(*Err)
+
+		if len(err.Stack) == 0 {
+			t.Fatal("expected stack to be not empty, got empty")
+		}
+
+		for i, entry := range err.Stack {
+			if entry.pc == 0 || entry.frame != nil {
+				t.Fatalf("expected entry %d to only hold a program counter, got %+v", i, entry)
+			}
+		}
+
+		lines := err.Stack.Strings()
+		if len(lines) < len(err.Stack) {
+			t.Fatalf("unexpected number of stack lines, got %d, expected at least %d", len(lines), len(err.Stack))
+		}
+
+		if !strings.Contains(lines[0], "TestStack") {
+			t.Errorf(`expected the first stack line to reference "TestStack", got "%s"`, lines[0])
+		}
+
+		if !strings.Contains(lines[0], "stack_test.go") {
+			t.Errorf(`expected the first stack line to reference "stack_test.go", got "%s"`, lines[0])
+		}
+	})
+
+	t.Run("when a stack is marshaled to JSON, it should be marshaled as an array of symbolized frames", func(t *testing.T) {
+		err := New("error message").(*Err)
+
+		b, jsonErr := json.Marshal(err.Stack)
+		if jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		var lines []string
+		if jsonErr := json.Unmarshal(b, &lines); jsonErr != nil {
+			t.Fatalf("unexpected error: %v", jsonErr)
+		}
+
+		if !strings.Contains(lines[0], "TestStack") {
+			t.Errorf(`expected the first stack line to reference "TestStack", got "%s"`, lines[0])
+		}
+	})
+
+	t.Run("when a stack is parsed, it should hold its own frames", func(t *testing.T) {
+		outer := ParseStack([]string{"main.query @ /app/db.go:12", "main.main @ /app/main.go:10"})
+		inner := ParseStack([]string{"main.connect @ /app/db.go:40", "main.main @ /app/main.go:10"})
+
+		frames := outer.Frames()
+		if len(frames) != 2 || frames[0].Function != "main.query" || frames[0].File != "/app/db.go" || frames[0].Line != 12 {
+			t.Errorf("unexpected frames, got %+v", frames)
+		}
+
+		if common := outer.commonSuffix(inner); common != 1 {
+			t.Errorf("unexpected number of shared frames, got %d, expected %d", common, 1)
+		}
+
+		if common := outer.commonSuffix(New("error message").(*Err).Stack); common != 0 {
+			t.Errorf("unexpected number of shared frames, got %d, expected %d", common, 0)
+		}
+	})
+
+	t.Run("when a nil stack is marshaled to JSON, it should be marshaled as null", func(t *testing.T) {
+		var st Stack
+
+		b, err := json.Marshal(st)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(b) != "null" {
+			t.Errorf(`unexpected JSON, got "%s", expected "null"`, b)
+		}
+	})
+}
+
+// newDedupInner creates the innermost error of the chain used by the deduplication tests.
+//
+//go:noinline
+func newDedupInner() error {
+	return New("inner error")
+}
+
+// newDedupOuter wraps the error created by newDedupInner.
+//
+//go:noinline
+func newDedupOuter() error {
+	err := newDedupInner()
+	return Wrap(err, "outer error")
+}
+
+func TestStackDedup(t *testing.T) {
+	t.Run("when stack deduplication is enabled, %+v should trim the frames shared with the cause", func(t *testing.T) {
+		defer SetStackDedup(StackDedupEnabled())
+		SetStackDedup(true)
+
+		outer := newDedupOuter().(*Err)
+		inner := outer.Cause.(*Err)
+
+		common := outer.Stack.commonSuffix(inner.Stack)
+		if common == 0 {
+			t.Fatal("expected the stacks to share frames, got none")
+		}
+
+		lines := stackLines(*outer)
+		if len(lines) != len(outer.Stack)-common+1 {
+			t.Fatalf("unexpected number of stack lines, got %d, expected %d", len(lines), len(outer.Stack)-common+1)
+		}
+
+		expected := fmt.Sprintf("... %d more", common)
+		if lines[len(lines)-1] != expected {
+			t.Errorf(`unexpected last stack line, got "%s", expected "%s"`, lines[len(lines)-1], expected)
+		}
+
+		outputStr := fmt.Sprintf("%+v", outer)
+		if strings.Count(outputStr, expected) != 1 {
+			t.Errorf(`expected "%s" to be in the output string once, got "%s"`, expected, outputStr)
+		}
+
+		if got := len(stackLines(*inner)); got != len(inner.Stack.Strings()) {
+			t.Errorf("unexpected number of stack lines for the innermost error, got %d, expected %d", got, len(inner.Stack.Strings()))
+		}
+	})
+
+	t.Run("when stack deduplication is enabled, MarshalJSON should trim the frames shared with the cause", func(t *testing.T) {
+		defer SetStackDedup(StackDedupEnabled())
+		SetStackDedup(true)
+
+		outer := newDedupOuter().(*Err)
+		common := outer.Stack.commonSuffix(outer.Cause.(*Err).Stack)
+
+		b, err := json.Marshal(outer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var errs []struct {
+			Stack []string `json:"stack"`
+		}
+		if err := json.Unmarshal(b, &errs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := fmt.Sprintf("... %d more", common)
+		if got := errs[0].Stack[len(errs[0].Stack)-1]; got != expected {
+			t.Errorf(`unexpected last stack line, got "%s", expected "%s"`, got, expected)
+		}
+
+		if got := errs[1].Stack[len(errs[1].Stack)-1]; strings.HasPrefix(got, "...") {
+			t.Errorf(`unexpected last stack line for the innermost error, got "%s"`, got)
+		}
+	})
+
+	t.Run("when stack deduplication is disabled, %+v should render the full stacks", func(t *testing.T) {
+		outer := newDedupOuter().(*Err)
+
+		if outputStr := fmt.Sprintf("%+v", outer); strings.Contains(outputStr, " more") {
+			t.Errorf(`expected no trimmed stacks in the output string, got "%s"`, outputStr)
+		}
+	})
+}
+
+func TestMergedStack(t *testing.T) {
+	t.Run("when MergedStack is provided with a chain of errors, it should insert the wrapper frames into the deepest stack", func(t *testing.T) {
+		outer := newDedupOuter().(*Err)
+		inner := outer.Cause.(*Err)
+
+		merged := MergedStack(fmt.Errorf("foreign wrapper: %w", outer))
+		if len(merged) != len(inner.Stack)+1 {
+			t.Fatalf("unexpected number of frames, got %d, expected %d", len(merged), len(inner.Stack)+1)
+		}
+
+		expected := append(Stack{inner.Stack[0], inner.Stack[1], outer.Stack[0]}, inner.Stack[2:]...)
+		if !reflect.DeepEqual(merged, expected) {
+			t.Errorf("unexpected merged stack, got %v, expected %v", merged.Strings(), expected.Strings())
+		}
+	})
+
+	t.Run("when MergedStack is provided with an error without stack, it should return nil", func(t *testing.T) {
+		if merged := MergedStack(fmt.Errorf("standard error")); merged != nil {
+			t.Errorf("expected merged stack to be nil, got %v", merged)
+		}
+	})
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = New("error message")
+	}
+}
+
+func BenchmarkWrap(b *testing.B) {
+	err := New("error message")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Wrap(err, "wrapped error message")
+	}
+}
+
+func BenchmarkErrordf(b *testing.B) {
+	data := Data{"id": 1}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Errordf(data, "error message %d", i)
+	}
+}
+
+// BenchmarkNewSymbolized measures the cost that used to be paid on every New call, when stacks were symbolized
+// eagerly.
+func BenchmarkNewSymbolized(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = New("error message").(*Err).Stack.Strings()
+	}
+}